@@ -0,0 +1,402 @@
+// Package cache keeps parsed transactions on disk so repeated runs against the same
+// large input skip parsing
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// indexMagic starts every index file; the digit is the format version
+const indexMagic = "MFSTIDX1"
+
+// FileIndex implements usecase.TransactionIndex with one file per local input in Dir.
+// An index is valid while the input keeps the path, size, modification time and
+// SHA-256 it was built from. When only the modification time changed, as after a copy
+// or touch, the content is hashed and the index is reused if it still matches.
+// Transactions are stored grouped by month behind a table of byte offsets, so a
+// lookup reads only the months it needs.
+type FileIndex struct {
+	Dir string
+	// VerifyContent hashes the input on every lookup instead of trusting an unchanged
+	// size and modification time
+	VerifyContent bool
+}
+
+func NewFileIndex(dir string) *FileIndex {
+	return &FileIndex{Dir: dir}
+}
+
+// DefaultDir is the mf-statement directory of the user cache directory
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", domain.NewIOError("failed to locate the user cache directory", err)
+	}
+	return filepath.Join(dir, "mf-statement"), nil
+}
+
+// indexHeader identifies the input an index was built from and where its months are
+type indexHeader struct {
+	Path    string
+	Variant string
+	Size    int64
+	ModTime int64
+	Hash    [sha256.Size]byte
+	Months  []monthEntry
+}
+
+// monthEntry locates the transactions of a month, yyyymm, in the data section
+type monthEntry struct {
+	Month  int
+	Offset int64
+	Count  int
+}
+
+func (x *FileIndex) Lookup(ctx context.Context, uri, variant string, start, end time.Time) ([]domain.Transaction, bool, error) {
+	path, ok := localPath(uri)
+	if !ok {
+		return nil, false, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	file, err := os.Open(x.indexPath(path, variant))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, domain.NewIOError("failed to open transaction index", err)
+	}
+	defer file.Close()
+
+	header, dataStart, err := readHeader(file)
+	if err != nil {
+		// Indexes of other versions or torn writes are rebuilt
+		return nil, false, nil
+	}
+	if header.Path != path || header.Variant != variant || header.Size != info.Size() {
+		return nil, false, nil
+	}
+	if header.ModTime != info.ModTime().UnixNano() || x.VerifyContent {
+		hash, err := hashFile(path)
+		if err != nil {
+			return nil, false, err
+		}
+		if hash != header.Hash {
+			return nil, false, nil
+		}
+	}
+
+	from, to := monthKey(start), monthKey(end)
+	type record struct {
+		seq uint64
+		tx  domain.Transaction
+	}
+	var records []record
+	for _, month := range header.Months {
+		if (!start.IsZero() && month.Month < from) || (!end.IsZero() && month.Month > to) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		default:
+		}
+
+		if _, err := file.Seek(dataStart+month.Offset, io.SeekStart); err != nil {
+			return nil, false, domain.NewIOError("failed to read transaction index", err)
+		}
+		d := &decoder{r: bufio.NewReader(file)}
+		for i := 0; i < month.Count && d.err == nil; i++ {
+			seq, tx := readTransaction(d)
+			records = append(records, record{seq, tx})
+		}
+		if d.err != nil {
+			return nil, false, nil
+		}
+	}
+
+	// Months are stored in order; the sequence numbers restore the input order
+	sort.Slice(records, func(i, j int) bool { return records[i].seq < records[j].seq })
+	transactions := make([]domain.Transaction, len(records))
+	for i, r := range records {
+		transactions[i] = r.tx
+	}
+	return transactions, true, nil
+}
+
+// Snapshot stats and hashes a local input. The input is stat'ed again after hashing,
+// and an input that changed meanwhile is not indexed this time.
+func (x *FileIndex) Snapshot(ctx context.Context, uri string) (*usecase.InputSnapshot, error) {
+	path, ok := localPath(uri)
+	if !ok {
+		return nil, nil
+	}
+	before, err := os.Stat(path)
+	if err != nil {
+		return nil, domain.NewIOError("failed to stat indexed input", err)
+	}
+	hash, err := hashFile(path)
+	if err != nil {
+		return nil, err
+	}
+	after, err := os.Stat(path)
+	if err != nil {
+		return nil, domain.NewIOError("failed to stat indexed input", err)
+	}
+	if after.Size() != before.Size() || !after.ModTime().Equal(before.ModTime()) {
+		return nil, nil
+	}
+	return &usecase.InputSnapshot{Path: path, Size: before.Size(), ModTime: before.ModTime(), SHA256: hash}, nil
+}
+
+func (x *FileIndex) Build(ctx context.Context, uri, variant string, snapshot *usecase.InputSnapshot, transactions []domain.Transaction) error {
+	if snapshot == nil {
+		return nil
+	}
+	path := snapshot.Path
+
+	byMonth := make(map[int][]int)
+	for i, tx := range transactions {
+		key := monthKey(tx.Date)
+		byMonth[key] = append(byMonth[key], i)
+	}
+	months := make([]int, 0, len(byMonth))
+	for month := range byMonth {
+		months = append(months, month)
+	}
+	sort.Ints(months)
+
+	header := indexHeader{Path: path, Variant: variant, Size: snapshot.Size, ModTime: snapshot.ModTime.UnixNano(), Hash: snapshot.SHA256}
+	var data bytes.Buffer
+	for _, month := range months {
+		header.Months = append(header.Months, monthEntry{Month: month, Offset: int64(data.Len()), Count: len(byMonth[month])})
+		for _, i := range byMonth[month] {
+			writeTransaction(&data, uint64(i), transactions[i])
+		}
+	}
+
+	var out bytes.Buffer
+	writeHeader(&out, header)
+	out.Write(data.Bytes())
+	return x.write(x.indexPath(path, variant), out.Bytes())
+}
+
+// write replaces the index atomically, so concurrent runs never read half an index
+func (x *FileIndex) write(indexPath string, content []byte) error {
+	if err := os.MkdirAll(x.Dir, 0o755); err != nil {
+		return domain.NewIOError("failed to create cache directory", err)
+	}
+	tmp, err := os.CreateTemp(x.Dir, ".index-*")
+	if err != nil {
+		return domain.NewIOError("failed to write transaction index", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return domain.NewIOError("failed to write transaction index", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return domain.NewIOError("failed to write transaction index", err)
+	}
+	if err := os.Rename(tmp.Name(), indexPath); err != nil {
+		return domain.NewIOError("failed to write transaction index", err)
+	}
+	return nil
+}
+
+// indexPath names the index after the input's absolute path and the parser variant
+func (x *FileIndex) indexPath(path, variant string) string {
+	sum := sha256.Sum256([]byte(path + "\x00" + variant))
+	return filepath.Join(x.Dir, hex.EncodeToString(sum[:12])+".idx")
+}
+
+// localPath returns the absolute path of file inputs; stdin and remote inputs are not indexed
+func localPath(uri string) (string, bool) {
+	if uri == "-" {
+		return "", false
+	}
+	if strings.Contains(uri, "://") {
+		if !strings.HasPrefix(uri, "file://") {
+			return "", false
+		}
+		uri = strings.TrimPrefix(uri, "file://")
+	}
+	path, err := filepath.Abs(uri)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+func monthKey(t time.Time) int {
+	return t.Year()*100 + int(t.Month())
+}
+
+func hashFile(path string) ([sha256.Size]byte, error) {
+	var hash [sha256.Size]byte
+	file, err := os.Open(path)
+	if err != nil {
+		return hash, domain.NewIOError("failed to open indexed input", err)
+	}
+	defer file.Close()
+
+	digest := sha256.New()
+	if _, err := io.Copy(digest, file); err != nil {
+		return hash, domain.NewIOError("failed to hash indexed input", err)
+	}
+	copy(hash[:], digest.Sum(nil))
+	return hash, nil
+}
+
+// writeHeader writes the magic, the header length as uint32 and the fields as varints
+// and length-prefixed strings; the data section follows the header
+func writeHeader(w *bytes.Buffer, header indexHeader) {
+	var body bytes.Buffer
+	writeString(&body, header.Path)
+	writeString(&body, header.Variant)
+	writeVarint(&body, header.Size)
+	writeVarint(&body, header.ModTime)
+	body.Write(header.Hash[:])
+	writeUvarint(&body, uint64(len(header.Months)))
+	for _, month := range header.Months {
+		writeUvarint(&body, uint64(month.Month))
+		writeUvarint(&body, uint64(month.Offset))
+		writeUvarint(&body, uint64(month.Count))
+	}
+
+	w.WriteString(indexMagic)
+	_ = binary.Write(w, binary.LittleEndian, uint32(body.Len()))
+	w.Write(body.Bytes())
+}
+
+// readHeader returns the header and the file offset of the data section
+func readHeader(r io.Reader) (indexHeader, int64, error) {
+	var header indexHeader
+	prefix := make([]byte, len(indexMagic)+4)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return header, 0, err
+	}
+	if string(prefix[:len(indexMagic)]) != indexMagic {
+		return header, 0, fmt.Errorf("not a transaction index")
+	}
+	length := binary.LittleEndian.Uint32(prefix[len(indexMagic):])
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return header, 0, err
+	}
+
+	d := &decoder{r: bytes.NewReader(body)}
+	header.Path = d.string()
+	header.Variant = d.string()
+	header.Size = d.varint()
+	header.ModTime = d.varint()
+	d.read(header.Hash[:])
+	for i, count := uint64(0), d.uvarint(); i < count && d.err == nil; i++ {
+		header.Months = append(header.Months, monthEntry{Month: int(d.uvarint()), Offset: int64(d.uvarint()), Count: int(d.uvarint())})
+	}
+	return header, int64(len(prefix)) + int64(length), d.err
+}
+
+// writeTransaction encodes the input position, the date in Unix nanoseconds, the
+// amount and the strings of a transaction
+func writeTransaction(w *bytes.Buffer, seq uint64, tx domain.Transaction) {
+	writeUvarint(w, seq)
+	writeVarint(w, tx.Date.UnixNano())
+	writeVarint(w, tx.Amount)
+	writeString(w, tx.Content)
+	writeString(w, tx.Category)
+	writeString(w, tx.ID)
+}
+
+func readTransaction(d *decoder) (uint64, domain.Transaction) {
+	seq := d.uvarint()
+	tx := domain.Transaction{Date: time.Unix(0, d.varint()).UTC()}
+	tx.Amount = d.varint()
+	tx.Content = d.string()
+	tx.Category = d.string()
+	tx.ID = d.string()
+	return seq, tx
+}
+
+func writeVarint(w *bytes.Buffer, v int64) {
+	w.Write(binary.AppendVarint(nil, v))
+}
+
+func writeUvarint(w *bytes.Buffer, v uint64) {
+	w.Write(binary.AppendUvarint(nil, v))
+}
+
+func writeString(w *bytes.Buffer, s string) {
+	writeUvarint(w, uint64(len(s)))
+	w.WriteString(s)
+}
+
+// maxStringLength bounds the strings of damaged indexes
+const maxStringLength = 1 << 20
+
+// decoder reads varints and strings, keeping the first error
+type decoder struct {
+	r interface {
+		io.Reader
+		io.ByteReader
+	}
+	err error
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	var v int64
+	v, d.err = binary.ReadVarint(d.r)
+	return v
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	var v uint64
+	v, d.err = binary.ReadUvarint(d.r)
+	return v
+}
+
+func (d *decoder) read(buf []byte) {
+	if d.err == nil {
+		_, d.err = io.ReadFull(d.r, buf)
+	}
+}
+
+func (d *decoder) string() string {
+	n := d.uvarint()
+	if d.err == nil && n > maxStringLength {
+		d.err = fmt.Errorf("string of %d bytes exceeds the index limit", n)
+	}
+	if d.err != nil {
+		return ""
+	}
+	buf := make([]byte, n)
+	d.read(buf)
+	return string(buf)
+}
@@ -0,0 +1,156 @@
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/cache"
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+var _ = Describe("FileIndex", func() {
+	var (
+		ctx          context.Context
+		index        *cache.FileIndex
+		inputPath    string
+		transactions []domain.Transaction
+	)
+
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	build := func(uri string, transactions []domain.Transaction) {
+		snapshot, err := index.Snapshot(ctx, uri)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(index.Build(ctx, uri, "csv", snapshot, transactions)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir := GinkgoT().TempDir()
+		index = cache.NewFileIndex(filepath.Join(dir, "cache"))
+		inputPath = filepath.Join(dir, "history.csv")
+		Expect(os.WriteFile(inputPath, []byte("date,amount,content\n..."), 0644)).To(Succeed())
+
+		// Input order interleaves months, as unsorted exports do
+		transactions = []domain.Transaction{
+			{Date: date(2025, 2, 1), Amount: -50, Content: "Bus"},
+			{Date: date(2024, 12, 24), Amount: -8000, Content: "Gifts", Category: "family"},
+			{Date: date(2025, 1, 5), Amount: 2000, Content: "Salary", ID: "FIT-7"},
+			{Date: date(2025, 1, 9), Amount: -300, Content: "Coffee"},
+		}
+		build(inputPath, transactions)
+	})
+
+	It("should return only the requested months in input order", func() {
+		found, ok, err := index.Lookup(ctx, inputPath, "csv", date(2025, 1, 1), date(2025, 2, 28))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(found).To(Equal([]domain.Transaction{transactions[0], transactions[2], transactions[3]}))
+	})
+
+	It("should return everything for an open range", func() {
+		found, ok, err := index.Lookup(ctx, "file://"+inputPath, "csv", time.Time{}, time.Time{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(found).To(Equal(transactions))
+	})
+
+	It("should miss when the input or the parser variant changed", func() {
+		_, ok, err := index.Lookup(ctx, inputPath, "tsv", time.Time{}, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		Expect(os.WriteFile(inputPath, []byte("date,amount,content\n.!."), 0644)).To(Succeed())
+		_, ok, err = index.Lookup(ctx, inputPath, "csv", time.Time{}, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should reuse the index when only the modification time changed", func() {
+		later := time.Now().Add(time.Hour)
+		Expect(os.Chtimes(inputPath, later, later)).To(Succeed())
+
+		found, ok, err := index.Lookup(ctx, inputPath, "csv", date(2024, 12, 1), date(2024, 12, 1))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(found).To(Equal(transactions[1:2]))
+	})
+
+	It("should not serve transactions of an input that changed while it was parsed", func() {
+		dir := filepath.Dir(inputPath)
+		changing := filepath.Join(dir, "changing.csv")
+		Expect(os.WriteFile(changing, []byte("date,amount,content\n2025/01/05,2000,Salary\n"), 0644)).To(Succeed())
+
+		// The source rewrites the input after handing out the original content, like
+		// an export job replacing the file during the parse
+		service := usecase.NewCachedTransactionService(usecase.NewTransactionService(rewritingSource{
+			content: "date,amount,content\n2025/01/05,2000,Salary\n2025/01/09,-300,Coffee\n",
+		}, parser.NewCSV()), index, "csv")
+
+		parsed, err := service.GetAllTransactions(ctx, changing)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(HaveLen(1))
+
+		_, ok, err := index.Lookup(ctx, changing, "csv", time.Time{}, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		reparsed, err := service.GetAllTransactions(ctx, changing)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reparsed).To(HaveLen(2))
+	})
+
+	It("should rebuild damaged indexes", func() {
+		entries, err := os.ReadDir(index.Dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		indexPath := filepath.Join(index.Dir, entries[0].Name())
+		data, err := os.ReadFile(indexPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(indexPath, data[:len(data)-3], 0644)).To(Succeed())
+
+		_, ok, err := index.Lookup(ctx, inputPath, "csv", time.Time{}, time.Time{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should not index stdin or remote inputs", func() {
+		build("https://example.com/history.csv", transactions)
+		build("-", transactions)
+
+		entries, err := os.ReadDir(index.Dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+})
+
+// rewritingSource reads a file and then replaces it with content
+type rewritingSource struct {
+	content string
+}
+
+func (r rewritingSource) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	data, err := os.ReadFile(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(uri, []byte(r.content), 0644); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
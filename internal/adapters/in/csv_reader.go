@@ -16,10 +16,15 @@ func NewCSVFileSource() *CSVFileSource {
 }
 
 func (s *CSVFileSource) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	return os.Open(LocalPath(uri))
+}
+
+// LocalPath returns the file path of a file:// URI, and plain paths unchanged
+func LocalPath(uri string) string {
 	if u, err := url.Parse(uri); err == nil && u.Scheme == "file" {
-		return os.Open(u.Path)
+		return u.Path
 	}
-	return os.Open(uri)
+	return uri
 }
 
 type CSVReaderService struct {
@@ -41,7 +46,7 @@ func (s *CSVReaderService) ReadTransactions(ctx context.Context, csvFileURI stri
 	}
 	defer csvReader.Close()
 
-	transactions, err := s.Parser.Parse(ctx, csvReader)
+	transactions, err := usecase.ParseInput(ctx, s.Parser, csvFileURI, csvReader)
 	if err != nil {
 		return nil, domain.NewParseError("failed to parse CSV", err)
 	}
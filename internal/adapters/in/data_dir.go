@@ -0,0 +1,63 @@
+package in
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+
+	"mf-statement/internal/domain"
+)
+
+// ResolveInDir maps a client supplied source name onto a file inside dir,
+// rejecting absolute paths and anything that would escape the directory, including
+// through symlinks. It returns the path with symlinks resolved.
+func ResolveInDir(dir, name string) (string, error) {
+	if dir == "" {
+		return "", domain.NewNotFoundError("source access (server started without a data directory)")
+	}
+	if name == "" {
+		return "", domain.NewValidationError("missing source parameter", nil)
+	}
+
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || !filepath.IsLocal(cleaned) {
+		return "", domain.NewValidationError("source must be a relative path inside the data directory", map[string]interface{}{
+			"source": name,
+		})
+	}
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", domain.NewIOError("failed to resolve the data directory", err)
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(root, cleaned))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", domain.NewNotFoundError(fmt.Sprintf("source %q", name))
+	}
+	if err != nil {
+		return "", domain.NewIOError("failed to resolve source", err)
+	}
+	if rel, err := filepath.Rel(root, resolved); err != nil || !filepath.IsLocal(rel) {
+		return "", domain.NewValidationError("source must be a relative path inside the data directory", map[string]interface{}{
+			"source": name,
+		})
+	}
+	return resolved, nil
+}
+
+// BytesSource serves in-memory CSV content regardless of the URI it is asked to open
+type BytesSource struct {
+	Content []byte
+}
+
+func NewBytesSource(content []byte) *BytesSource {
+	return &BytesSource{Content: content}
+}
+
+func (s *BytesSource) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.Content)), nil
+}
@@ -0,0 +1,62 @@
+package in_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/in"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("ResolveInDir", func() {
+	var dataDir, outside string
+
+	BeforeEach(func() {
+		dataDir = GinkgoT().TempDir()
+		outside = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dataDir, "transactions.csv"), []byte("date,amount,content\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(outside, "secret.csv"), []byte("date,amount,content\n"), 0644)).To(Succeed())
+	})
+
+	It("should resolve sources inside the directory", func() {
+		path, err := in.ResolveInDir(dataDir, "transactions.csv")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Base(path)).To(Equal("transactions.csv"))
+	})
+
+	It("should follow symlinks that stay inside the directory", func() {
+		Expect(os.Symlink("transactions.csv", filepath.Join(dataDir, "latest.csv"))).To(Succeed())
+
+		path, err := in.ResolveInDir(dataDir, "latest.csv")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Base(path)).To(Equal("transactions.csv"))
+	})
+
+	It("should reject symlinks escaping the directory", func() {
+		Expect(os.Symlink(filepath.Join(outside, "secret.csv"), filepath.Join(dataDir, "secret.csv"))).To(Succeed())
+		Expect(os.Symlink(outside, filepath.Join(dataDir, "linked"))).To(Succeed())
+
+		_, err := in.ResolveInDir(dataDir, "secret.csv")
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+
+		_, err = in.ResolveInDir(dataDir, "linked/secret.csv")
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+
+	It("should reject paths escaping the directory", func() {
+		_, err := in.ResolveInDir(dataDir, "../secret.csv")
+
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+
+	It("should report missing sources as not found", func() {
+		_, err := in.ResolveInDir(dataDir, "missing.csv")
+
+		Expect(err).To(MatchError(ContainSubstring(`source "missing.csv" not found`)))
+	})
+})
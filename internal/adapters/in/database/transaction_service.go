@@ -0,0 +1,324 @@
+// Package database reads transactions straight from a SQL database through database/sql
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// DefaultQuery selects the columns of the default mapping from a transactions table
+const DefaultQuery = "SELECT date, amount, content FROM transactions"
+
+// PlaceholderStyle is the bind parameter syntax of the SQL driver
+type PlaceholderStyle string
+
+const (
+	PlaceholderQuestion PlaceholderStyle = "?"
+	PlaceholderDollar   PlaceholderStyle = "$"
+)
+
+// PlaceholderForDriver returns the bind parameter syntax of a registered driver name
+func PlaceholderForDriver(driver string) PlaceholderStyle {
+	switch driver {
+	case "pgx", "postgres", "postgresql":
+		return PlaceholderDollar
+	default:
+		return PlaceholderQuestion
+	}
+}
+
+// Columns maps transaction fields to result columns of the query; Category is optional
+type Columns struct {
+	Date     string
+	Amount   string
+	Content  string
+	Category string
+}
+
+func DefaultColumns() Columns {
+	return Columns{Date: "date", Amount: "amount", Content: "content"}
+}
+
+// Config describes the query producing transactions. Query may select any columns as
+// long as the mapped ones are present; period and date range filters are added as a
+// WHERE clause around it so only matching rows leave the database.
+type Config struct {
+	Query        string
+	Columns      Columns
+	Placeholders PlaceholderStyle
+	// DateFormat binds and parses dates as text in this layout, for databases such as
+	// SQLite that store dates as strings. Empty binds time.Time values. The bounds are
+	// compared as text, so the layout must sort like the dates it formats.
+	DateFormat string
+}
+
+// TransactionService implements usecase.TransactionService on top of a SQL query.
+// The URI argument of the interface methods is ignored; the query defines the data.
+type TransactionService struct {
+	DB        *sql.DB
+	Config    Config
+	Validator usecase.Validator
+}
+
+func NewTransactionService(db *sql.DB, cfg Config) (*TransactionService, error) {
+	if cfg.Query == "" {
+		cfg.Query = DefaultQuery
+	}
+	if cfg.Columns == (Columns{}) {
+		cfg.Columns = DefaultColumns()
+	}
+	if cfg.Placeholders == "" {
+		cfg.Placeholders = PlaceholderQuestion
+	}
+	if err := cfg.Columns.validate(); err != nil {
+		return nil, err
+	}
+	if err := ValidateDateFormat(cfg.DateFormat); err != nil {
+		return nil, err
+	}
+
+	return &TransactionService{
+		DB:        db,
+		Config:    cfg,
+		Validator: usecase.NewPeriodValidator(),
+	}, nil
+}
+
+func (s *TransactionService) GetAllTransactions(ctx context.Context, uri string) ([]domain.Transaction, error) {
+	return s.query(ctx, s.Config.Query)
+}
+
+func (s *TransactionService) GetTransactionsByPeriod(ctx context.Context, uri string, year, month int) ([]domain.Transaction, error) {
+	if err := s.Validator.ValidatePeriod(year, month); err != nil {
+		return nil, err
+	}
+
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	where := fmt.Sprintf("t.%s >= %s AND t.%s < %s", s.Config.Columns.Date, s.placeholder(1), s.Config.Columns.Date, s.placeholder(2))
+	return s.query(ctx, s.filtered(where), s.dateArg(start), s.dateArg(start.AddDate(0, 1, 0)))
+}
+
+func (s *TransactionService) GetTransactionsByDateRange(ctx context.Context, uri string, startDate, endDate time.Time) ([]domain.Transaction, error) {
+	where := fmt.Sprintf("t.%s >= %s AND t.%s <= %s", s.Config.Columns.Date, s.placeholder(1), s.Config.Columns.Date, s.placeholder(2))
+	return s.query(ctx, s.filtered(where), s.dateArg(startDate), s.dateArg(endDate))
+}
+
+func (s *TransactionService) CalculateTotals(transactions []domain.Transaction) (totalIncome, totalExpenditure int64) {
+	for _, transaction := range transactions {
+		if transaction.IsIncome() {
+			totalIncome += transaction.Amount
+		} else if transaction.IsExpense() {
+			totalExpenditure += transaction.Amount
+		}
+	}
+	return totalIncome, totalExpenditure
+}
+
+func (s *TransactionService) filtered(where string) string {
+	return "SELECT * FROM (" + s.Config.Query + ") AS t WHERE " + where
+}
+
+func (s *TransactionService) placeholder(n int) string {
+	if s.Config.Placeholders == PlaceholderDollar {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+func (s *TransactionService) dateArg(t time.Time) interface{} {
+	if s.Config.DateFormat != "" {
+		return t.Format(s.Config.DateFormat)
+	}
+	return t
+}
+
+func (s *TransactionService) query(ctx context.Context, query string, args ...interface{}) ([]domain.Transaction, error) {
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, domain.NewIOError("failed to query transactions", err)
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, domain.NewIOError("failed to read result columns", err)
+	}
+	index, err := s.Config.Columns.indexIn(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []domain.Transaction
+	values := make([]interface{}, len(names))
+	pointers := make([]interface{}, len(names))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for row := 1; rows.Next(); row++ {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, domain.NewIOError("failed to read transaction row", err)
+		}
+
+		tx, err := s.toTransaction(values, index)
+		if err != nil {
+			return nil, domain.NewParseError(fmt.Sprintf("invalid transaction in row %d", row), err)
+		}
+		transactions = append(transactions, tx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.NewIOError("failed to read transactions", err)
+	}
+
+	// Newest first like the CSV services; ties keep the query order
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Date.After(transactions[j].Date)
+	})
+	return transactions, nil
+}
+
+type columnIndex struct {
+	date, amount, content, category int
+}
+
+func (s *TransactionService) toTransaction(values []interface{}, index columnIndex) (domain.Transaction, error) {
+	date, err := s.parseDate(values[index.date])
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	amount, err := parseAmount(values[index.amount])
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+
+	tx, err := domain.NewTransaction(date, amount, asString(values[index.content]))
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	if index.category >= 0 {
+		tx.Category = asString(values[index.category])
+	}
+	return tx, nil
+}
+
+func (s *TransactionService) parseDate(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string, []byte:
+		layout := s.Config.DateFormat
+		if layout == "" {
+			layout = time.DateOnly
+		}
+		date, err := time.Parse(layout, asString(v))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q: %w", asString(v), err)
+		}
+		return date, nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported date value %v (%T)", value, value)
+	}
+}
+
+func parseAmount(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int32:
+		return int64(v), nil
+	case string, []byte:
+		amount, err := strconv.ParseInt(strings.TrimSpace(asString(v)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q: %w", asString(v), err)
+		}
+		return amount, nil
+	default:
+		return 0, fmt.Errorf("unsupported amount value %v (%T)", value, value)
+	}
+}
+
+func asString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// sortableDatePattern matches fixed-width layouts running from the year down, optionally
+// followed by a time of day, whose text sorts in date order
+var sortableDatePattern = regexp.MustCompile(`^2006[-/.]?01[-/.]?02([T ]15(:?04(:?05(\.0+)?)?)?)?$`)
+
+// ValidateDateFormat rejects text date layouts that do not sort in date order, such as
+// 01/02/2006, since the period filters compare formatted dates as strings
+func ValidateDateFormat(layout string) error {
+	if layout == "" || sortableDatePattern.MatchString(layout) {
+		return nil
+	}
+	return domain.NewValidationError(fmt.Sprintf("date format %q does not sort in date order; use a layout such as 2006-01-02", layout), map[string]interface{}{
+		"layout": layout,
+	})
+}
+
+func (c Columns) validate() error {
+	for field, name := range map[string]string{"date": c.Date, "amount": c.Amount, "content": c.Content, "category": c.Category} {
+		if name == "" && field == "category" {
+			continue
+		}
+		if !identifierPattern.MatchString(name) {
+			return domain.NewValidationError("invalid column name", map[string]interface{}{
+				"field":  field,
+				"column": name,
+			})
+		}
+	}
+	return nil
+}
+
+func (c Columns) indexIn(names []string) (columnIndex, error) {
+	find := func(column string) (int, error) {
+		for i, name := range names {
+			if strings.EqualFold(name, column) {
+				return i, nil
+			}
+		}
+		return -1, domain.NewValidationError(fmt.Sprintf("query result has no column %q", column), map[string]interface{}{
+			"column":  column,
+			"columns": names,
+		})
+	}
+
+	index := columnIndex{category: -1}
+	var err error
+	if index.date, err = find(c.Date); err != nil {
+		return columnIndex{}, err
+	}
+	if index.amount, err = find(c.Amount); err != nil {
+		return columnIndex{}, err
+	}
+	if index.content, err = find(c.Content); err != nil {
+		return columnIndex{}, err
+	}
+	if c.Category != "" {
+		if index.category, err = find(c.Category); err != nil {
+			return columnIndex{}, err
+		}
+	}
+	return index, nil
+}
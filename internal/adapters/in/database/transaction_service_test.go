@@ -0,0 +1,134 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	_ "modernc.org/sqlite"
+
+	"mf-statement/internal/adapters/in/database"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("TransactionService", func() {
+	var (
+		db  *sql.DB
+		ctx context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = sql.Open("sqlite", ":memory:")
+		Expect(err).NotTo(HaveOccurred())
+		// Every connection to :memory: is a separate database
+		db.SetMaxOpenConns(1)
+		DeferCleanup(db.Close)
+		ctx = context.Background()
+
+		_, err = db.Exec(`
+			CREATE TABLE entries (id INTEGER PRIMARY KEY, posted_on TEXT, cents INTEGER, memo TEXT, tag TEXT);
+			INSERT INTO entries (posted_on, cents, memo, tag) VALUES
+				('2024-12-31', 500, 'Refund', NULL),
+				('2025-01-01', 2000, 'Salary', 'income'),
+				('2025-01-09', -300, 'Grocery', 'food'),
+				('2025-01-31', -100, 'Coffee', 'food'),
+				('2025-02-01', -50, 'Bus', 'transport');
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	newService := func(cfg database.Config) *database.TransactionService {
+		if cfg.Query == "" {
+			cfg.Query = "SELECT posted_on, cents, memo, tag FROM entries ORDER BY id"
+		}
+		if cfg.Columns == (database.Columns{}) {
+			cfg.Columns = database.Columns{Date: "posted_on", Amount: "cents", Content: "memo", Category: "tag"}
+		}
+		cfg.DateFormat = "2006-01-02"
+		service, err := database.NewTransactionService(db, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		return service
+	}
+
+	It("should map columns onto transactions, newest first", func() {
+		transactions, err := newService(database.Config{}).GetAllTransactions(ctx, "")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(5))
+		Expect(transactions[0]).To(Equal(domain.Transaction{
+			Date:     time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			Amount:   -50,
+			Content:  "Bus",
+			Category: "transport",
+		}))
+		Expect(transactions[4].Category).To(BeEmpty())
+	})
+
+	It("should filter the period in SQL", func() {
+		transactions, err := newService(database.Config{}).GetTransactionsByPeriod(ctx, "", 2025, 1)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(3))
+		Expect(transactions[0].Content).To(Equal("Coffee"))
+		Expect(transactions[2].Content).To(Equal("Salary"))
+	})
+
+	It("should use $n placeholders when configured", func() {
+		service := newService(database.Config{Placeholders: database.PlaceholderDollar})
+
+		transactions, err := service.GetTransactionsByDateRange(ctx, "",
+			time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(2))
+	})
+
+	It("should validate the period", func() {
+		_, err := newService(database.Config{}).GetTransactionsByPeriod(ctx, "", 2025, 13)
+
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+
+	It("should report queries missing a mapped column", func() {
+		service := newService(database.Config{Query: "SELECT posted_on, cents FROM entries"})
+
+		_, err := service.GetAllTransactions(ctx, "")
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("memo"))
+	})
+
+	It("should report rows that cannot be converted as parse errors", func() {
+		_, err := db.Exec(`INSERT INTO entries (posted_on, cents, memo) VALUES ('01/05/2025', 1, 'Bad date')`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = newService(database.Config{}).GetAllTransactions(ctx, "")
+		Expect(domain.IsParseError(err)).To(BeTrue())
+	})
+
+	It("should report rows without content as parse errors", func() {
+		_, err := db.Exec(`INSERT INTO entries (posted_on, cents, memo) VALUES ('2025-01-10', 1, '  ')`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = newService(database.Config{}).GetTransactionsByPeriod(ctx, "", 2025, 1)
+		Expect(domain.IsParseError(err)).To(BeTrue())
+	})
+
+	It("should reject date formats that do not sort in date order", func() {
+		_, err := database.NewTransactionService(db, database.Config{DateFormat: "01/02/2006"})
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+
+		_, err = database.NewTransactionService(db, database.Config{DateFormat: "2006/01/02 15:04"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject column names that are not identifiers", func() {
+		_, err := database.NewTransactionService(db, database.Config{
+			Columns: database.Columns{Date: "date; DROP TABLE entries", Amount: "cents", Content: "memo"},
+		})
+
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+})
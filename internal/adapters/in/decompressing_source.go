@@ -0,0 +1,250 @@
+package in
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"mf-statement/internal/usecase"
+)
+
+// Compression identifies an input encoding by its magic bytes
+type Compression string
+
+const (
+	CompressionNone  Compression = ""
+	CompressionGzip  Compression = "gzip"
+	CompressionBzip2 Compression = "bzip2"
+	CompressionZstd  Compression = "zstd"
+	CompressionZip   Compression = "zip"
+)
+
+var magics = []struct {
+	compression Compression
+	magic       []byte
+}{
+	{CompressionGzip, []byte{0x1f, 0x8b}},
+	{CompressionBzip2, []byte("BZh")},
+	{CompressionZstd, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{CompressionZip, []byte("PK\x03\x04")},
+}
+
+// DetectCompression matches the start of an input against the known magic bytes
+func DetectCompression(header []byte) Compression {
+	for _, m := range magics {
+		if bytes.HasPrefix(header, m.magic) {
+			return m.compression
+		}
+	}
+	return CompressionNone
+}
+
+// DefaultMaxDecompressedBytes bounds decompressed uploads to the servers
+const DefaultMaxDecompressedBytes int64 = 256 << 20
+
+// ErrDecompressedTooLarge is reported once a compressed input expands past MaxBytes
+var ErrDecompressedTooLarge = errors.New("decompressed input exceeds the size limit")
+
+// DecompressingSource wraps a source and transparently decompresses gzip, bzip2,
+// zstd and single-file zip inputs. The format is detected from the content, so
+// misnamed files and stdin pipelines work; plain input passes through unchanged.
+// MaxBytes, when positive, limits what a compressed input may expand to, including
+// the archives buffered in memory for zip.
+type DecompressingSource struct {
+	Source   usecase.Source
+	MaxBytes int64
+}
+
+func NewDecompressingSource(source usecase.Source) *DecompressingSource {
+	return &DecompressingSource{Source: source}
+}
+
+func (s *DecompressingSource) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	rc, err := s.Source.Open(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	buffered := bufio.NewReader(rc)
+	// A short or empty input simply does not match any magic
+	header, _ := buffered.Peek(4)
+
+	compression := DetectCompression(header)
+	reader, err := decompress(compression, buffered, rc, s.MaxBytes)
+	if err != nil {
+		rc.Close()
+		return nil, fmt.Errorf("%s: %w", uri, err)
+	}
+	if compression != CompressionNone && s.MaxBytes > 0 {
+		reader = &stackedReadCloser{Reader: &sizeLimitedReader{r: reader, remaining: s.MaxBytes}, closers: []io.Closer{reader}}
+	}
+	return reader, nil
+}
+
+func decompress(compression Compression, buffered *bufio.Reader, underlying io.ReadCloser, maxBytes int64) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return &stackedReadCloser{Reader: gz, closers: []io.Closer{gz, underlying}}, nil
+	case CompressionBzip2:
+		return &stackedReadCloser{Reader: bzip2.NewReader(buffered), closers: []io.Closer{underlying}}, nil
+	case CompressionZstd:
+		zr, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return &stackedReadCloser{Reader: zr, closers: []io.Closer{zstdCloser{zr}, underlying}}, nil
+	case CompressionZip:
+		return openZipEntry(buffered, underlying, maxBytes)
+	default:
+		return &stackedReadCloser{Reader: buffered, closers: []io.Closer{underlying}}, nil
+	}
+}
+
+// openZipEntry opens the only file of a zip archive. Zip needs random access, so
+// non-file inputs are buffered in memory, up to maxBytes when it is positive. Office
+// documents such as .xlsx workbooks are zip packages too; they are passed through
+// whole for their parser.
+func openZipEntry(buffered *bufio.Reader, underlying io.ReadCloser, maxBytes int64) (io.ReadCloser, error) {
+	var (
+		readerAt io.ReaderAt
+		size     int64
+	)
+	if file, ok := underlying.(*os.File); ok {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if info.Mode().IsRegular() {
+			readerAt, size = file, info.Size()
+		}
+	}
+	if readerAt == nil {
+		archiveReader := io.Reader(buffered)
+		if maxBytes > 0 {
+			archiveReader = &sizeLimitedReader{r: buffered, remaining: maxBytes}
+		}
+		content, err := io.ReadAll(archiveReader)
+		if err != nil {
+			return nil, err
+		}
+		readerAt, size = bytes.NewReader(content), int64(len(content))
+	}
+
+	archive, err := zip.NewReader(readerAt, size)
+	if err != nil {
+		return nil, err
+	}
+	if isOfficePackage(archive.File) {
+		return &stackedReadCloser{Reader: io.NewSectionReader(readerAt, 0, size), closers: []io.Closer{underlying}}, nil
+	}
+
+	entry, err := singleEntry(archive.File)
+	if err != nil {
+		return nil, err
+	}
+	entryReader, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	return &stackedReadCloser{Reader: entryReader, closers: []io.Closer{entryReader, underlying}}, nil
+}
+
+// singleEntry picks the archive's only file, preferring the only .csv file when
+// the archive also carries other files such as a README
+func singleEntry(files []*zip.File) (*zip.File, error) {
+	var regular, csvFiles []*zip.File
+	for _, f := range files {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		regular = append(regular, f)
+		if strings.EqualFold(path.Ext(f.Name), ".csv") {
+			csvFiles = append(csvFiles, f)
+		}
+	}
+
+	switch {
+	case len(regular) == 1:
+		return regular[0], nil
+	case len(csvFiles) == 1:
+		return csvFiles[0], nil
+	case len(regular) == 0:
+		return nil, errors.New("zip archive is empty")
+	default:
+		return nil, fmt.Errorf("zip archive contains %d files; expected a single CSV", len(regular))
+	}
+}
+
+// isOfficePackage reports whether a zip archive is an Office Open XML document,
+// which always carries a [Content_Types].xml part
+func isOfficePackage(files []*zip.File) bool {
+	for _, f := range files {
+		if f.Name == "[Content_Types].xml" {
+			return true
+		}
+	}
+	return false
+}
+
+// sizeLimitedReader fails with ErrDecompressedTooLarge instead of ending quietly like
+// io.LimitReader, so a truncated input is never parsed as if it were complete
+type sizeLimitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// Input ending exactly at the limit is fine; one more byte is not
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrDecompressedTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// stackedReadCloser reads from the outermost reader and closes every layer
+type stackedReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (s *stackedReadCloser) Close() error {
+	var errs []error
+	for _, c := range s.closers {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+type zstdCloser struct {
+	decoder *zstd.Decoder
+}
+
+func (z zstdCloser) Close() error {
+	z.decoder.Close()
+	return nil
+}
@@ -0,0 +1,164 @@
+package in_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/in"
+)
+
+// bzip2 of httpCSV; the standard library can only decompress bzip2
+var bzip2CSV = []byte("\x42\x5a\x68\x39\x31\x41\x59\x26\x53\x59\xbd\x13\x58\xcf\x00\x00\x0d\x5b\x80\x00\x10\x00\x04\xf2\x00\x08\x00\x2e\x07\x96\x20\x20\x00\x21\xa9\xe9\x34\x1a\x00\x68\x53\x09\xa6\x80\xd3\x13\xaf\x59\xe5\x63\x55\x0c\xc4\xa3\x32\x05\x8d\x8e\xb4\x93\xb0\xc8\xc3\xfb\x9d\x97\x81\x2a\xff\x17\x72\x45\x38\x50\x90\xbd\x13\x58\xcf")
+
+func gzipBytes(content string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, _ = io.WriteString(w, content)
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+func zstdBytes(content string) []byte {
+	var buf bytes.Buffer
+	w, _ := zstd.NewWriter(&buf)
+	_, _ = io.WriteString(w, content)
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+func zipBytes(files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, _ := w.Create(name)
+		_, _ = io.WriteString(f, content)
+	}
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+func readAllFrom(source *in.DecompressingSource, uri string) (string, error) {
+	reader, err := source.Open(context.Background(), uri)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	return string(content), err
+}
+
+var _ = Describe("DecompressingSource", func() {
+	DescribeTable("should decompress by content",
+		func(content []byte) {
+			source := in.NewDecompressingSource(in.NewBytesSource(content))
+
+			Expect(readAllFrom(source, "transactions")).To(Equal(httpCSV))
+		},
+		Entry("plain", []byte(httpCSV)),
+		Entry("gzip", gzipBytes(httpCSV)),
+		Entry("bzip2", bzip2CSV),
+		Entry("zstd", zstdBytes(httpCSV)),
+		Entry("zip", zipBytes(map[string]string{"transactions.csv": httpCSV})),
+	)
+
+	It("should pass through empty input", func() {
+		source := in.NewDecompressingSource(in.NewBytesSource(nil))
+
+		Expect(readAllFrom(source, "empty.csv")).To(BeEmpty())
+	})
+
+	It("should detect compression regardless of the file name", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "transactions.csv")
+		Expect(os.WriteFile(path, gzipBytes(httpCSV), 0644)).To(Succeed())
+
+		source := in.NewDecompressingSource(in.NewCSVFileSource())
+		Expect(readAllFrom(source, path)).To(Equal(httpCSV))
+	})
+
+	It("should read zip archives from files without buffering", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "2025-01.zip")
+		Expect(os.WriteFile(path, zipBytes(map[string]string{"README.txt": "export", "2025-01.csv": httpCSV}), 0644)).To(Succeed())
+
+		source := in.NewDecompressingSource(in.NewCSVFileSource())
+		Expect(readAllFrom(source, path)).To(Equal(httpCSV))
+	})
+
+	It("should pass Office documents through as whole archives", func() {
+		xlsx := zipBytes(map[string]string{"[Content_Types].xml": "<Types/>", "xl/workbook.xml": "<workbook/>"})
+		source := in.NewDecompressingSource(in.NewBytesSource(xlsx))
+
+		Expect(readAllFrom(source, "statement.xlsx")).To(Equal(string(xlsx)))
+	})
+
+	It("should reject zip archives without a single CSV", func() {
+		source := in.NewDecompressingSource(in.NewBytesSource(zipBytes(map[string]string{"a.csv": "", "b.csv": ""})))
+
+		_, err := readAllFrom(source, "exports.zip")
+		Expect(err).To(MatchError(ContainSubstring("contains 2 files")))
+	})
+
+	Context("with MaxBytes", func() {
+		It("should read input that expands to exactly the limit", func() {
+			source := in.NewDecompressingSource(in.NewBytesSource(gzipBytes(httpCSV)))
+			source.MaxBytes = int64(len(httpCSV))
+
+			Expect(readAllFrom(source, "transactions.csv.gz")).To(Equal(httpCSV))
+		})
+
+		It("should fail once compressed input expands past the limit", func() {
+			source := in.NewDecompressingSource(in.NewBytesSource(gzipBytes(strings.Repeat("0", 1<<20))))
+			source.MaxBytes = 1 << 10
+
+			_, err := readAllFrom(source, "bomb.gz")
+			Expect(err).To(MatchError(in.ErrDecompressedTooLarge))
+		})
+
+		It("should not buffer zip archives larger than the limit", func() {
+			archive := zipBytes(map[string]string{"transactions.csv": httpCSV})
+			source := in.NewDecompressingSource(in.NewBytesSource(archive))
+			source.MaxBytes = int64(len(archive) - 1)
+
+			_, err := readAllFrom(source, "transactions.zip")
+			Expect(err).To(MatchError(in.ErrDecompressedTooLarge))
+		})
+
+		It("should leave plain input alone", func() {
+			source := in.NewDecompressingSource(in.NewBytesSource([]byte(httpCSV)))
+			source.MaxBytes = 1
+
+			Expect(readAllFrom(source, "transactions.csv")).To(Equal(httpCSV))
+		})
+	})
+
+	It("should report corrupt compressed input", func() {
+		corrupt := gzipBytes(httpCSV)[:20]
+		source := in.NewDecompressingSource(in.NewBytesSource(corrupt))
+
+		_, err := readAllFrom(source, "corrupt.gz")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("StdinSource", func() {
+	It("should be selected by the registry for -", func() {
+		registry := in.NewSourceRegistry()
+		registry.Register(in.StdinScheme, in.NewStdinSource(strings.NewReader(httpCSV)))
+
+		reader, err := registry.Open(context.Background(), in.StdinURI)
+		Expect(err).NotTo(HaveOccurred())
+		content, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(httpCSV))
+	})
+})
@@ -0,0 +1,243 @@
+package in
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"mf-statement/internal/domain"
+)
+
+const (
+	DefaultWatchDebounce     = 500 * time.Millisecond
+	DefaultWatchPollInterval = 2 * time.Second
+)
+
+// FileWatcher reports changes to files and to the files of directories. It relies
+// on file system notifications and falls back to polling where they are not
+// available. Bursts of writes, such as an export job writing a file in chunks,
+// are reported once they settle.
+type FileWatcher struct {
+	// Paths are the files, directories and glob patterns to watch
+	Paths    []string
+	Debounce time.Duration
+	// PollInterval is how often files are compared when polling
+	PollInterval time.Duration
+	// Poll skips notifications, e.g. for network file systems that do not deliver them
+	Poll bool
+	// OnFallback is called with the reason when notifications are unavailable
+	OnFallback func(err error)
+}
+
+func NewFileWatcher(paths []string) *FileWatcher {
+	return &FileWatcher{Paths: paths, Debounce: DefaultWatchDebounce, PollInterval: DefaultWatchPollInterval}
+}
+
+// Watch calls onChange after every settled burst of changes until ctx is done or
+// onChange returns an error
+func (w *FileWatcher) Watch(ctx context.Context, onChange func(ctx context.Context) error) error {
+	if len(w.Paths) == 0 {
+		return domain.NewValidationError("nothing to watch", nil)
+	}
+
+	changes := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+
+	errs := make(chan error, 1)
+	if w.Poll {
+		go func() { errs <- w.poll(ctx, notify) }()
+	} else {
+		watcher, err := w.notifications()
+		if err != nil {
+			if w.OnFallback != nil {
+				w.OnFallback(err)
+			}
+			go func() { errs <- w.poll(ctx, notify) }()
+		} else {
+			defer watcher.Close()
+			go func() { errs <- w.forward(ctx, watcher, notify) }()
+		}
+	}
+
+	var (
+		timer   *time.Timer
+		settled <-chan time.Time
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case <-changes:
+			// Every change restarts the quiet period
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(w.Debounce)
+			settled = timer.C
+		case <-settled:
+			settled = nil
+			if err := onChange(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// notifications watches the directories of the paths, since files are often
+// replaced by renaming rather than written in place
+func (w *FileWatcher) notifications() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range w.Paths {
+		dir := path
+		if !isDir(path) {
+			dir = filepath.Dir(path)
+		}
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return nil, err
+		}
+	}
+	return watcher, nil
+}
+
+func (w *FileWatcher) forward(ctx context.Context, watcher *fsnotify.Watcher, notify func()) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op != fsnotify.Chmod && w.watches(event.Name) {
+				notify()
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return domain.NewIOError("file notifications failed", err)
+		}
+	}
+}
+
+// watches reports whether a changed file matches one of the paths or is inside a watched directory
+func (w *FileWatcher) watches(name string) bool {
+	name = filepath.Clean(name)
+	for _, path := range w.Paths {
+		path = filepath.Clean(path)
+		if isDir(path) {
+			if filepath.Dir(name) == path {
+				return true
+			}
+			continue
+		}
+		if matched, _ := filepath.Match(path, name); matched || name == path {
+			return true
+		}
+	}
+	return false
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+func (w *FileWatcher) poll(ctx context.Context, notify func()) error {
+	previous, err := w.snapshot()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(w.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			current, err := w.snapshot()
+			if err != nil {
+				return err
+			}
+			if !sameFiles(previous, current) {
+				notify()
+			}
+			previous = current
+		}
+	}
+}
+
+// snapshot records the size and modification time of the watched files; missing
+// files are left out so that their creation counts as a change
+func (w *FileWatcher) snapshot() (map[string]fileState, error) {
+	states := map[string]fileState{}
+	record := func(path string, info fs.FileInfo) {
+		states[path] = fileState{size: info.Size(), modTime: info.ModTime()}
+	}
+
+	for _, path := range w.Paths {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, domain.NewValidationError("invalid watch pattern", map[string]interface{}{"pattern": path})
+		}
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, domain.NewIOError("failed to stat watched path", err)
+			}
+			if !info.IsDir() {
+				record(match, info)
+				continue
+			}
+
+			entries, err := os.ReadDir(match)
+			if err != nil {
+				return nil, domain.NewIOError("failed to list watched directory", err)
+			}
+			for _, entry := range entries {
+				if info, err := entry.Info(); err == nil && !entry.IsDir() {
+					record(filepath.Join(match, entry.Name()), info)
+				}
+			}
+		}
+	}
+	return states, nil
+}
+
+func sameFiles(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		other, ok := b[path]
+		if !ok || other.size != state.size || !other.modTime.Equal(state.modTime) {
+			return false
+		}
+	}
+	return true
+}
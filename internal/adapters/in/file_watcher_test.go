@@ -0,0 +1,94 @@
+package in_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/in"
+)
+
+var _ = Describe("FileWatcher", func() {
+	var (
+		dir     string
+		changes atomic.Int32
+		cancel  context.CancelFunc
+		done    chan error
+	)
+
+	start := func(watcher *in.FileWatcher) {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		done = make(chan error, 1)
+		go func() {
+			done <- watcher.Watch(ctx, func(context.Context) error {
+				changes.Add(1)
+				return nil
+			})
+		}()
+		// Let the watcher take its first look before files change
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		changes.Store(0)
+		Expect(os.WriteFile(filepath.Join(dir, "a.csv"), []byte("date,amount,content\n"), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	DescribeTable("should report a burst of writes once",
+		func(poll bool) {
+			watcher := in.NewFileWatcher([]string{filepath.Join(dir, "*.csv")})
+			watcher.Debounce = 200 * time.Millisecond
+			watcher.Poll, watcher.PollInterval = poll, 20*time.Millisecond
+			start(watcher)
+
+			for i := 0; i < 5; i++ {
+				Expect(os.WriteFile(filepath.Join(dir, "a.csv"), []byte("date,amount,content\n2025/01/01,"+string(rune('1'+i))+",x\n"), 0644)).To(Succeed())
+				time.Sleep(30 * time.Millisecond)
+			}
+
+			Eventually(changes.Load, "2s").Should(BeEquivalentTo(1))
+			Consistently(changes.Load, "400ms").Should(BeEquivalentTo(1))
+		},
+		Entry("with notifications", false),
+		Entry("when polling", true),
+	)
+
+	DescribeTable("should report new files matching a pattern and ignore other files",
+		func(poll bool) {
+			watcher := in.NewFileWatcher([]string{filepath.Join(dir, "*.csv")})
+			watcher.Debounce = 50 * time.Millisecond
+			watcher.Poll, watcher.PollInterval = poll, 20*time.Millisecond
+			start(watcher)
+
+			Expect(os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("todo"), 0644)).To(Succeed())
+			Consistently(changes.Load, "300ms").Should(BeZero())
+
+			Expect(os.WriteFile(filepath.Join(dir, "b.csv"), []byte("date,amount,content\n"), 0644)).To(Succeed())
+			Eventually(changes.Load, "2s").Should(BeEquivalentTo(1))
+		},
+		Entry("with notifications", false),
+		Entry("when polling", true),
+	)
+
+	It("should watch every file of a directory", func() {
+		watcher := in.NewFileWatcher([]string{dir})
+		watcher.Debounce = 50 * time.Millisecond
+		start(watcher)
+
+		Expect(os.WriteFile(filepath.Join(dir, "2025-02.csv"), []byte("date,amount,content\n"), 0644)).To(Succeed())
+
+		Eventually(changes.Load, "2s").Should(BeEquivalentTo(1))
+	})
+})
@@ -0,0 +1,33 @@
+package grpcapi
+
+import (
+	"strconv"
+
+	statementv1 "mf-statement/internal/adapters/in/grpcapi/pb/statement/v1"
+	"mf-statement/internal/domain"
+)
+
+func toProtoStatement(s domain.Statement) *statementv1.Statement {
+	transactions := make([]*statementv1.Transaction, len(s.Transactions))
+	for i, tx := range s.Transactions {
+		// DTO amounts are always formatted from int64 values
+		amount, _ := strconv.ParseInt(tx.Amount, 10, 64)
+		transactions[i] = toProtoTransaction(tx, amount)
+	}
+
+	return &statementv1.Statement{
+		Period:           s.Period,
+		TotalIncome:      s.TotalIncome,
+		TotalExpenditure: s.TotalExpenditure,
+		Transactions:     transactions,
+	}
+}
+
+func toProtoTransaction(tx domain.TransactionDTO, amount int64) *statementv1.Transaction {
+	return &statementv1.Transaction{
+		Date:     tx.Date,
+		Amount:   amount,
+		Content:  tx.Content,
+		Category: tx.Category,
+	}
+}
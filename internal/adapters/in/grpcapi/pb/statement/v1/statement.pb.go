@@ -0,0 +1,773 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: statement/v1/statement.proto
+
+package statementv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CSVInput is either the CSV content itself or the name of a file in the
+// server data directory.
+type CSVInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Input:
+	//
+	//	*CSVInput_Content
+	//	*CSVInput_Source
+	Input         isCSVInput_Input `protobuf_oneof:"input"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CSVInput) Reset() {
+	*x = CSVInput{}
+	mi := &file_statement_v1_statement_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CSVInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CSVInput) ProtoMessage() {}
+
+func (x *CSVInput) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CSVInput.ProtoReflect.Descriptor instead.
+func (*CSVInput) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CSVInput) GetInput() isCSVInput_Input {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *CSVInput) GetContent() []byte {
+	if x != nil {
+		if x, ok := x.Input.(*CSVInput_Content); ok {
+			return x.Content
+		}
+	}
+	return nil
+}
+
+func (x *CSVInput) GetSource() string {
+	if x != nil {
+		if x, ok := x.Input.(*CSVInput_Source); ok {
+			return x.Source
+		}
+	}
+	return ""
+}
+
+type isCSVInput_Input interface {
+	isCSVInput_Input()
+}
+
+type CSVInput_Content struct {
+	Content []byte `protobuf:"bytes,1,opt,name=content,proto3,oneof"`
+}
+
+type CSVInput_Source struct {
+	Source string `protobuf:"bytes,2,opt,name=source,proto3,oneof"`
+}
+
+func (*CSVInput_Content) isCSVInput_Input() {}
+
+func (*CSVInput_Source) isCSVInput_Input() {}
+
+type GenerateStatementRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Month in YYYYMM format.
+	Period        string    `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	Input         *CSVInput `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateStatementRequest) Reset() {
+	*x = GenerateStatementRequest{}
+	mi := &file_statement_v1_statement_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStatementRequest) ProtoMessage() {}
+
+func (x *GenerateStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStatementRequest.ProtoReflect.Descriptor instead.
+func (*GenerateStatementRequest) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GenerateStatementRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *GenerateStatementRequest) GetInput() *CSVInput {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+type GenerateStatementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statement     *Statement             `protobuf:"bytes,1,opt,name=statement,proto3" json:"statement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateStatementResponse) Reset() {
+	*x = GenerateStatementResponse{}
+	mi := &file_statement_v1_statement_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStatementResponse) ProtoMessage() {}
+
+func (x *GenerateStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStatementResponse.ProtoReflect.Descriptor instead.
+func (*GenerateStatementResponse) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GenerateStatementResponse) GetStatement() *Statement {
+	if x != nil {
+		return x.Statement
+	}
+	return nil
+}
+
+type StreamTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Month in YYYYMM format.
+	Period        string    `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	Input         *CSVInput `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTransactionsRequest) Reset() {
+	*x = StreamTransactionsRequest{}
+	mi := &file_statement_v1_statement_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTransactionsRequest) ProtoMessage() {}
+
+func (x *StreamTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*StreamTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamTransactionsRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *StreamTransactionsRequest) GetInput() *CSVInput {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+type ValidateCSVRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Input         *CSVInput              `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCSVRequest) Reset() {
+	*x = ValidateCSVRequest{}
+	mi := &file_statement_v1_statement_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCSVRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCSVRequest) ProtoMessage() {}
+
+func (x *ValidateCSVRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCSVRequest.ProtoReflect.Descriptor instead.
+func (*ValidateCSVRequest) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ValidateCSVRequest) GetInput() *CSVInput {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+type Transaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Date in YYYY/MM/DD format.
+	Date          string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Amount        int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Content       string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Category      string `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_statement_v1_statement_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Transaction) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Transaction) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type Statement struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Period           string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	TotalIncome      int64                  `protobuf:"varint,2,opt,name=total_income,json=totalIncome,proto3" json:"total_income,omitempty"`
+	TotalExpenditure int64                  `protobuf:"varint,3,opt,name=total_expenditure,json=totalExpenditure,proto3" json:"total_expenditure,omitempty"`
+	Transactions     []*Transaction         `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Statement) Reset() {
+	*x = Statement{}
+	mi := &file_statement_v1_statement_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Statement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statement) ProtoMessage() {}
+
+func (x *Statement) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statement.ProtoReflect.Descriptor instead.
+func (*Statement) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Statement) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *Statement) GetTotalIncome() int64 {
+	if x != nil {
+		return x.TotalIncome
+	}
+	return 0
+}
+
+func (x *Statement) GetTotalExpenditure() int64 {
+	if x != nil {
+		return x.TotalExpenditure
+	}
+	return 0
+}
+
+func (x *Statement) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type Summary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Period           string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	TotalIncome      int64                  `protobuf:"varint,2,opt,name=total_income,json=totalIncome,proto3" json:"total_income,omitempty"`
+	TotalExpenditure int64                  `protobuf:"varint,3,opt,name=total_expenditure,json=totalExpenditure,proto3" json:"total_expenditure,omitempty"`
+	TransactionCount int64                  `protobuf:"varint,4,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Summary) Reset() {
+	*x = Summary{}
+	mi := &file_statement_v1_statement_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Summary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Summary) ProtoMessage() {}
+
+func (x *Summary) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Summary.ProtoReflect.Descriptor instead.
+func (*Summary) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Summary) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *Summary) GetTotalIncome() int64 {
+	if x != nil {
+		return x.TotalIncome
+	}
+	return 0
+}
+
+func (x *Summary) GetTotalExpenditure() int64 {
+	if x != nil {
+		return x.TotalExpenditure
+	}
+	return 0
+}
+
+func (x *Summary) GetTransactionCount() int64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+type StreamTransactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Record:
+	//
+	//	*StreamTransactionsResponse_Transaction
+	//	*StreamTransactionsResponse_Summary
+	Record        isStreamTransactionsResponse_Record `protobuf_oneof:"record"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTransactionsResponse) Reset() {
+	*x = StreamTransactionsResponse{}
+	mi := &file_statement_v1_statement_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTransactionsResponse) ProtoMessage() {}
+
+func (x *StreamTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*StreamTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamTransactionsResponse) GetRecord() isStreamTransactionsResponse_Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *StreamTransactionsResponse) GetTransaction() *Transaction {
+	if x != nil {
+		if x, ok := x.Record.(*StreamTransactionsResponse_Transaction); ok {
+			return x.Transaction
+		}
+	}
+	return nil
+}
+
+func (x *StreamTransactionsResponse) GetSummary() *Summary {
+	if x != nil {
+		if x, ok := x.Record.(*StreamTransactionsResponse_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+type isStreamTransactionsResponse_Record interface {
+	isStreamTransactionsResponse_Record()
+}
+
+type StreamTransactionsResponse_Transaction struct {
+	Transaction *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3,oneof"`
+}
+
+type StreamTransactionsResponse_Summary struct {
+	Summary *Summary `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+}
+
+func (*StreamTransactionsResponse_Transaction) isStreamTransactionsResponse_Record() {}
+
+func (*StreamTransactionsResponse_Summary) isStreamTransactionsResponse_Record() {}
+
+type ValidateCSVResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Valid            bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	TransactionCount int64                  `protobuf:"varint,2,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	// Error describes the first problem found when valid is false.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCSVResponse) Reset() {
+	*x = ValidateCSVResponse{}
+	mi := &file_statement_v1_statement_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCSVResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCSVResponse) ProtoMessage() {}
+
+func (x *ValidateCSVResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_statement_v1_statement_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCSVResponse.ProtoReflect.Descriptor instead.
+func (*ValidateCSVResponse) Descriptor() ([]byte, []int) {
+	return file_statement_v1_statement_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidateCSVResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateCSVResponse) GetTransactionCount() int64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *ValidateCSVResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_statement_v1_statement_proto protoreflect.FileDescriptor
+
+const file_statement_v1_statement_proto_rawDesc = "" +
+	"\n" +
+	"\x1cstatement/v1/statement.proto\x12\fstatement.v1\"I\n" +
+	"\bCSVInput\x12\x1a\n" +
+	"\acontent\x18\x01 \x01(\fH\x00R\acontent\x12\x18\n" +
+	"\x06source\x18\x02 \x01(\tH\x00R\x06sourceB\a\n" +
+	"\x05input\"`\n" +
+	"\x18GenerateStatementRequest\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12,\n" +
+	"\x05input\x18\x02 \x01(\v2\x16.statement.v1.CSVInputR\x05input\"R\n" +
+	"\x19GenerateStatementResponse\x125\n" +
+	"\tstatement\x18\x01 \x01(\v2\x17.statement.v1.StatementR\tstatement\"a\n" +
+	"\x19StreamTransactionsRequest\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12,\n" +
+	"\x05input\x18\x02 \x01(\v2\x16.statement.v1.CSVInputR\x05input\"B\n" +
+	"\x12ValidateCSVRequest\x12,\n" +
+	"\x05input\x18\x01 \x01(\v2\x16.statement.v1.CSVInputR\x05input\"o\n" +
+	"\vTransaction\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\"\xb2\x01\n" +
+	"\tStatement\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12!\n" +
+	"\ftotal_income\x18\x02 \x01(\x03R\vtotalIncome\x12+\n" +
+	"\x11total_expenditure\x18\x03 \x01(\x03R\x10totalExpenditure\x12=\n" +
+	"\ftransactions\x18\x04 \x03(\v2\x19.statement.v1.TransactionR\ftransactions\"\x9e\x01\n" +
+	"\aSummary\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12!\n" +
+	"\ftotal_income\x18\x02 \x01(\x03R\vtotalIncome\x12+\n" +
+	"\x11total_expenditure\x18\x03 \x01(\x03R\x10totalExpenditure\x12+\n" +
+	"\x11transaction_count\x18\x04 \x01(\x03R\x10transactionCount\"\x98\x01\n" +
+	"\x1aStreamTransactionsResponse\x12=\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x19.statement.v1.TransactionH\x00R\vtransaction\x121\n" +
+	"\asummary\x18\x02 \x01(\v2\x15.statement.v1.SummaryH\x00R\asummaryB\b\n" +
+	"\x06record\"n\n" +
+	"\x13ValidateCSVResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12+\n" +
+	"\x11transaction_count\x18\x02 \x01(\x03R\x10transactionCount\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error2\xb7\x02\n" +
+	"\x10StatementService\x12d\n" +
+	"\x11GenerateStatement\x12&.statement.v1.GenerateStatementRequest\x1a'.statement.v1.GenerateStatementResponse\x12i\n" +
+	"\x12StreamTransactions\x12'.statement.v1.StreamTransactionsRequest\x1a(.statement.v1.StreamTransactionsResponse0\x01\x12R\n" +
+	"\vValidateCSV\x12 .statement.v1.ValidateCSVRequest\x1a!.statement.v1.ValidateCSVResponseBGZEmf-statement/internal/adapters/in/grpcapi/pb/statement/v1;statementv1b\x06proto3"
+
+var (
+	file_statement_v1_statement_proto_rawDescOnce sync.Once
+	file_statement_v1_statement_proto_rawDescData []byte
+)
+
+func file_statement_v1_statement_proto_rawDescGZIP() []byte {
+	file_statement_v1_statement_proto_rawDescOnce.Do(func() {
+		file_statement_v1_statement_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_statement_v1_statement_proto_rawDesc), len(file_statement_v1_statement_proto_rawDesc)))
+	})
+	return file_statement_v1_statement_proto_rawDescData
+}
+
+var file_statement_v1_statement_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_statement_v1_statement_proto_goTypes = []any{
+	(*CSVInput)(nil),                   // 0: statement.v1.CSVInput
+	(*GenerateStatementRequest)(nil),   // 1: statement.v1.GenerateStatementRequest
+	(*GenerateStatementResponse)(nil),  // 2: statement.v1.GenerateStatementResponse
+	(*StreamTransactionsRequest)(nil),  // 3: statement.v1.StreamTransactionsRequest
+	(*ValidateCSVRequest)(nil),         // 4: statement.v1.ValidateCSVRequest
+	(*Transaction)(nil),                // 5: statement.v1.Transaction
+	(*Statement)(nil),                  // 6: statement.v1.Statement
+	(*Summary)(nil),                    // 7: statement.v1.Summary
+	(*StreamTransactionsResponse)(nil), // 8: statement.v1.StreamTransactionsResponse
+	(*ValidateCSVResponse)(nil),        // 9: statement.v1.ValidateCSVResponse
+}
+var file_statement_v1_statement_proto_depIdxs = []int32{
+	0,  // 0: statement.v1.GenerateStatementRequest.input:type_name -> statement.v1.CSVInput
+	6,  // 1: statement.v1.GenerateStatementResponse.statement:type_name -> statement.v1.Statement
+	0,  // 2: statement.v1.StreamTransactionsRequest.input:type_name -> statement.v1.CSVInput
+	0,  // 3: statement.v1.ValidateCSVRequest.input:type_name -> statement.v1.CSVInput
+	5,  // 4: statement.v1.Statement.transactions:type_name -> statement.v1.Transaction
+	5,  // 5: statement.v1.StreamTransactionsResponse.transaction:type_name -> statement.v1.Transaction
+	7,  // 6: statement.v1.StreamTransactionsResponse.summary:type_name -> statement.v1.Summary
+	1,  // 7: statement.v1.StatementService.GenerateStatement:input_type -> statement.v1.GenerateStatementRequest
+	3,  // 8: statement.v1.StatementService.StreamTransactions:input_type -> statement.v1.StreamTransactionsRequest
+	4,  // 9: statement.v1.StatementService.ValidateCSV:input_type -> statement.v1.ValidateCSVRequest
+	2,  // 10: statement.v1.StatementService.GenerateStatement:output_type -> statement.v1.GenerateStatementResponse
+	8,  // 11: statement.v1.StatementService.StreamTransactions:output_type -> statement.v1.StreamTransactionsResponse
+	9,  // 12: statement.v1.StatementService.ValidateCSV:output_type -> statement.v1.ValidateCSVResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_statement_v1_statement_proto_init() }
+func file_statement_v1_statement_proto_init() {
+	if File_statement_v1_statement_proto != nil {
+		return
+	}
+	file_statement_v1_statement_proto_msgTypes[0].OneofWrappers = []any{
+		(*CSVInput_Content)(nil),
+		(*CSVInput_Source)(nil),
+	}
+	file_statement_v1_statement_proto_msgTypes[8].OneofWrappers = []any{
+		(*StreamTransactionsResponse_Transaction)(nil),
+		(*StreamTransactionsResponse_Summary)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_statement_v1_statement_proto_rawDesc), len(file_statement_v1_statement_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_statement_v1_statement_proto_goTypes,
+		DependencyIndexes: file_statement_v1_statement_proto_depIdxs,
+		MessageInfos:      file_statement_v1_statement_proto_msgTypes,
+	}.Build()
+	File_statement_v1_statement_proto = out.File
+	file_statement_v1_statement_proto_goTypes = nil
+	file_statement_v1_statement_proto_depIdxs = nil
+}
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: statement/v1/statement.proto
+
+package statementv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StatementService_GenerateStatement_FullMethodName  = "/statement.v1.StatementService/GenerateStatement"
+	StatementService_StreamTransactions_FullMethodName = "/statement.v1.StatementService/StreamTransactions"
+	StatementService_ValidateCSV_FullMethodName        = "/statement.v1.StatementService/ValidateCSV"
+)
+
+// StatementServiceClient is the client API for StatementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StatementService generates monthly statements from transaction CSVs.
+type StatementServiceClient interface {
+	// GenerateStatement returns the statement of a period.
+	GenerateStatement(ctx context.Context, in *GenerateStatementRequest, opts ...grpc.CallOption) (*GenerateStatementResponse, error)
+	// StreamTransactions streams the transactions of a period in input order,
+	// followed by a single summary message.
+	StreamTransactions(ctx context.Context, in *StreamTransactionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamTransactionsResponse], error)
+	// ValidateCSV checks that a CSV parses without generating a statement.
+	ValidateCSV(ctx context.Context, in *ValidateCSVRequest, opts ...grpc.CallOption) (*ValidateCSVResponse, error)
+}
+
+type statementServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatementServiceClient(cc grpc.ClientConnInterface) StatementServiceClient {
+	return &statementServiceClient{cc}
+}
+
+func (c *statementServiceClient) GenerateStatement(ctx context.Context, in *GenerateStatementRequest, opts ...grpc.CallOption) (*GenerateStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateStatementResponse)
+	err := c.cc.Invoke(ctx, StatementService_GenerateStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statementServiceClient) StreamTransactions(ctx context.Context, in *StreamTransactionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamTransactionsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StatementService_ServiceDesc.Streams[0], StatementService_StreamTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTransactionsRequest, StreamTransactionsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatementService_StreamTransactionsClient = grpc.ServerStreamingClient[StreamTransactionsResponse]
+
+func (c *statementServiceClient) ValidateCSV(ctx context.Context, in *ValidateCSVRequest, opts ...grpc.CallOption) (*ValidateCSVResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateCSVResponse)
+	err := c.cc.Invoke(ctx, StatementService_ValidateCSV_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatementServiceServer is the server API for StatementService service.
+// All implementations must embed UnimplementedStatementServiceServer
+// for forward compatibility.
+//
+// StatementService generates monthly statements from transaction CSVs.
+type StatementServiceServer interface {
+	// GenerateStatement returns the statement of a period.
+	GenerateStatement(context.Context, *GenerateStatementRequest) (*GenerateStatementResponse, error)
+	// StreamTransactions streams the transactions of a period in input order,
+	// followed by a single summary message.
+	StreamTransactions(*StreamTransactionsRequest, grpc.ServerStreamingServer[StreamTransactionsResponse]) error
+	// ValidateCSV checks that a CSV parses without generating a statement.
+	ValidateCSV(context.Context, *ValidateCSVRequest) (*ValidateCSVResponse, error)
+	mustEmbedUnimplementedStatementServiceServer()
+}
+
+// UnimplementedStatementServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatementServiceServer struct{}
+
+func (UnimplementedStatementServiceServer) GenerateStatement(context.Context, *GenerateStatementRequest) (*GenerateStatementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateStatement not implemented")
+}
+func (UnimplementedStatementServiceServer) StreamTransactions(*StreamTransactionsRequest, grpc.ServerStreamingServer[StreamTransactionsResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamTransactions not implemented")
+}
+func (UnimplementedStatementServiceServer) ValidateCSV(context.Context, *ValidateCSVRequest) (*ValidateCSVResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidateCSV not implemented")
+}
+func (UnimplementedStatementServiceServer) mustEmbedUnimplementedStatementServiceServer() {}
+func (UnimplementedStatementServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeStatementServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatementServiceServer will
+// result in compilation errors.
+type UnsafeStatementServiceServer interface {
+	mustEmbedUnimplementedStatementServiceServer()
+}
+
+func RegisterStatementServiceServer(s grpc.ServiceRegistrar, srv StatementServiceServer) {
+	// If the following call panics, it indicates UnimplementedStatementServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatementService_ServiceDesc, srv)
+}
+
+func _StatementService_GenerateStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).GenerateStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_GenerateStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).GenerateStatement(ctx, req.(*GenerateStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatementService_StreamTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatementServiceServer).StreamTransactions(m, &grpc.GenericServerStream[StreamTransactionsRequest, StreamTransactionsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatementService_StreamTransactionsServer = grpc.ServerStreamingServer[StreamTransactionsResponse]
+
+func _StatementService_ValidateCSV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateCSVRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).ValidateCSV(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_ValidateCSV_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).ValidateCSV(ctx, req.(*ValidateCSVRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatementService_ServiceDesc is the grpc.ServiceDesc for StatementService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatementService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "statement.v1.StatementService",
+	HandlerType: (*StatementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenerateStatement",
+			Handler:    _StatementService_GenerateStatement_Handler,
+		},
+		{
+			MethodName: "ValidateCSV",
+			Handler:    _StatementService_ValidateCSV_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTransactions",
+			Handler:       _StatementService_StreamTransactions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "statement/v1/statement.proto",
+}
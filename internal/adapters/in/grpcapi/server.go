@@ -0,0 +1,204 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"mf-statement/internal/adapters/in"
+	statementv1 "mf-statement/internal/adapters/in/grpcapi/pb/statement/v1"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+	"mf-statement/internal/util"
+)
+
+// Server implements the statement.v1.StatementService gRPC API on top of the usecase layer
+type Server struct {
+	statementv1.UnimplementedStatementServiceServer
+
+	// DataDir is the directory named sources are resolved in; named sources are rejected when empty
+	DataDir string
+	Source  usecase.Source
+	// MaxDecompressedBytes limits what compressed inline content may expand to
+	MaxDecompressedBytes int64
+	Parsers              *in.ParserRegistry
+	// Format is the input format; auto detects it from the content and source name
+	Format string
+	Logger *util.Logger
+}
+
+func NewServer(dataDir string, logger *util.Logger) *Server {
+	return &Server{
+		DataDir:              dataDir,
+		Source:               in.NewDecompressingSource(in.NewCSVFileSource()),
+		MaxDecompressedBytes: in.DefaultMaxDecompressedBytes,
+		Parsers:              in.NewDefaultParserRegistry(),
+		Format:               in.FormatAuto,
+		Logger:               logger,
+	}
+}
+
+// Register attaches the service to a gRPC server
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	statementv1.RegisterStatementServiceServer(registrar, s)
+}
+
+func (s *Server) GenerateStatement(ctx context.Context, req *statementv1.GenerateStatementRequest) (*statementv1.GenerateStatementResponse, error) {
+	year, month, display, err := parsePeriod(req.GetPeriod())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	source, uri, err := s.resolveInput(req.GetInput())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	inputParser, err := s.Parsers.Parser(s.Format)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	writer := &output.StatementCapture{}
+	transactionService := usecase.NewTransactionService(source, inputParser)
+	statementService := usecase.NewStatementService(transactionService, writer)
+
+	if err := statementService.GenerateMonthlyStatement(ctx, uri, display, year, month); err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return &statementv1.GenerateStatementResponse{Statement: toProtoStatement(writer.Statement)}, nil
+}
+
+func (s *Server) StreamTransactions(req *statementv1.StreamTransactionsRequest, stream grpc.ServerStreamingServer[statementv1.StreamTransactionsResponse]) error {
+	year, month, display, err := parsePeriod(req.GetPeriod())
+	if err != nil {
+		return s.toStatus(err)
+	}
+
+	source, uri, err := s.resolveInput(req.GetInput())
+	if err != nil {
+		return s.toStatus(err)
+	}
+	inputParser, err := s.Parsers.Parser(s.Format)
+	if err != nil {
+		return s.toStatus(err)
+	}
+
+	service := usecase.NewStreamingStatementService(source, inputParser)
+	if err := service.StreamMonthlyStatement(stream.Context(), []string{uri}, display, year, month, streamWriter{stream: stream}); err != nil {
+		return s.toStatus(err)
+	}
+	return nil
+}
+
+func (s *Server) ValidateCSV(ctx context.Context, req *statementv1.ValidateCSVRequest) (*statementv1.ValidateCSVResponse, error) {
+	source, uri, err := s.resolveInput(req.GetInput())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	inputParser, err := s.Parsers.Parser(s.Format)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	transactions, err := usecase.NewTransactionService(source, inputParser).GetAllTransactions(ctx, uri)
+	if err != nil {
+		if domain.IsParseError(err) && ctx.Err() == nil {
+			return &statementv1.ValidateCSVResponse{Valid: false, Error: err.Error()}, nil
+		}
+		return nil, s.toStatus(err)
+	}
+
+	return &statementv1.ValidateCSVResponse{Valid: true, TransactionCount: int64(len(transactions))}, nil
+}
+
+func (s *Server) resolveInput(input *statementv1.CSVInput) (usecase.Source, string, error) {
+	switch v := input.GetInput().(type) {
+	case *statementv1.CSVInput_Content:
+		source := in.NewDecompressingSource(in.NewBytesSource(v.Content))
+		source.MaxBytes = s.MaxDecompressedBytes
+		return source, "request", nil
+	case *statementv1.CSVInput_Source:
+		path, err := in.ResolveInDir(s.DataDir, v.Source)
+		if err != nil {
+			return nil, "", err
+		}
+		return s.Source, path, nil
+	default:
+		return nil, "", domain.NewValidationError("missing CSV input", nil)
+	}
+}
+
+func parsePeriod(period string) (year, month int, display string, err error) {
+	year, month, display, err = util.ParseYYYYMM(period)
+	if err != nil {
+		return 0, 0, "", domain.NewValidationError("invalid period format", map[string]interface{}{
+			"period": period,
+			"error":  err.Error(),
+		})
+	}
+	return year, month, display, nil
+}
+
+func (s *Server) toStatus(err error) error {
+	code := CodeForError(err)
+	if s.Logger != nil {
+		s.Logger.Warn("RPC failed", "code", code.String(), "error", err)
+	}
+	return status.Error(code, err.Error())
+}
+
+// CodeForError maps domain error types onto gRPC status codes
+func CodeForError(err error) codes.Code {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, fs.ErrNotExist):
+		return codes.NotFound
+	case errors.Is(err, in.ErrDecompressedTooLarge):
+		return codes.ResourceExhausted
+	}
+
+	var domainErr domain.DomainError
+	if !errors.As(err, &domainErr) {
+		return codes.Internal
+	}
+
+	switch domainErr.Type {
+	case domain.ErrorTypeValidation, domain.ErrorTypeParse:
+		return codes.InvalidArgument
+	case domain.ErrorTypeNotFound:
+		return codes.NotFound
+	default:
+		return codes.Internal
+	}
+}
+
+// streamWriter adapts the server stream to output.StreamWriter
+type streamWriter struct {
+	stream grpc.ServerStreamingServer[statementv1.StreamTransactionsResponse]
+}
+
+func (w streamWriter) WriteTransaction(ctx context.Context, tx domain.Transaction) error {
+	return w.stream.Send(&statementv1.StreamTransactionsResponse{
+		Record: &statementv1.StreamTransactionsResponse_Transaction{Transaction: toProtoTransaction(domain.NewTransactionDTO(tx), tx.Amount)},
+	})
+}
+
+func (w streamWriter) WriteSummary(ctx context.Context, summary domain.Summary) error {
+	return w.stream.Send(&statementv1.StreamTransactionsResponse{
+		Record: &statementv1.StreamTransactionsResponse_Summary{Summary: &statementv1.Summary{
+			Period:           summary.Period,
+			TotalIncome:      summary.TotalIncome,
+			TotalExpenditure: summary.TotalExpenditure,
+			TransactionCount: int64(summary.TransactionCount),
+		}},
+	})
+}
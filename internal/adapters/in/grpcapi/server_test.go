@@ -0,0 +1,175 @@
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"mf-statement/internal/adapters/in"
+	"mf-statement/internal/adapters/in/grpcapi"
+	statementv1 "mf-statement/internal/adapters/in/grpcapi/pb/statement/v1"
+	"mf-statement/internal/domain"
+)
+
+const sampleCSV = `date,amount,content
+2025/01/05,2000,Salary
+2025/02/01,999,Next Month
+2025/01/09,-300,Grocery
+`
+
+var _ = Describe("Server", func() {
+	var (
+		ctx        context.Context
+		tempDir    string
+		grpcServer *grpc.Server
+		conn       *grpc.ClientConn
+		client     statementv1.StatementServiceClient
+	)
+
+	inline := func(content string) *statementv1.CSVInput {
+		return &statementv1.CSVInput{Input: &statementv1.CSVInput_Content{Content: []byte(content)}}
+	}
+
+	BeforeEach(func() {
+		var err error
+		ctx = context.Background()
+		tempDir, err = os.MkdirTemp("", "grpcapi_test_*")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(tempDir, "transactions.csv"), []byte(sampleCSV), 0644)).To(Succeed())
+
+		listener := bufconn.Listen(1 << 20)
+		grpcServer = grpc.NewServer()
+		grpcapi.NewServer(tempDir, nil).Register(grpcServer)
+		go grpcServer.Serve(listener)
+
+		conn, err = grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		client = statementv1.NewStatementServiceClient(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		grpcServer.Stop()
+		os.RemoveAll(tempDir)
+	})
+
+	Context("GenerateStatement", func() {
+		It("should generate a statement from inline CSV", func() {
+			resp, err := client.GenerateStatement(ctx, &statementv1.GenerateStatementRequest{Period: "202501", Input: inline(sampleCSV)})
+
+			Expect(err).NotTo(HaveOccurred())
+			statement := resp.GetStatement()
+			Expect(statement.GetPeriod()).To(Equal("2025/01"))
+			Expect(statement.GetTotalIncome()).To(Equal(int64(2000)))
+			Expect(statement.GetTotalExpenditure()).To(Equal(int64(-300)))
+			Expect(statement.GetTransactions()).To(HaveLen(2))
+			Expect(statement.GetTransactions()[0].GetAmount()).To(Equal(int64(-300)))
+		})
+
+		It("should detect the format of inline content", func() {
+			ofx := "OFXHEADER:100\nDATA:OFXSGML\n\n<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>USD<BANKTRANLIST>" +
+				"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20250112<TRNAMT>-4.25<FITID>1<NAME>Bakery</STMTTRN>" +
+				"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>"
+			resp, err := client.GenerateStatement(ctx, &statementv1.GenerateStatementRequest{Period: "202501", Input: inline(ofx)})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.GetStatement().GetTotalExpenditure()).To(Equal(int64(-425)))
+		})
+
+		It("should read named sources from the data directory", func() {
+			input := &statementv1.CSVInput{Input: &statementv1.CSVInput_Source{Source: "transactions.csv"}}
+			resp, err := client.GenerateStatement(ctx, &statementv1.GenerateStatementRequest{Period: "202502", Input: input})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.GetStatement().GetTotalIncome()).To(Equal(int64(999)))
+		})
+
+		It("should return InvalidArgument for an invalid period", func() {
+			_, err := client.GenerateStatement(ctx, &statementv1.GenerateStatementRequest{Period: "2025", Input: inline(sampleCSV)})
+
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should return NotFound for a missing source", func() {
+			input := &statementv1.CSVInput{Input: &statementv1.CSVInput_Source{Source: "missing.csv"}}
+			_, err := client.GenerateStatement(ctx, &statementv1.GenerateStatementRequest{Period: "202501", Input: input})
+
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+
+		It("should return InvalidArgument without input", func() {
+			_, err := client.GenerateStatement(ctx, &statementv1.GenerateStatementRequest{Period: "202501"})
+
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+	})
+
+	Context("StreamTransactions", func() {
+		It("should stream transactions in input order followed by a summary", func() {
+			stream, err := client.StreamTransactions(ctx, &statementv1.StreamTransactionsRequest{Period: "202501", Input: inline(sampleCSV)})
+			Expect(err).NotTo(HaveOccurred())
+
+			var responses []*statementv1.StreamTransactionsResponse
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).NotTo(HaveOccurred())
+				responses = append(responses, resp)
+			}
+
+			Expect(responses).To(HaveLen(3))
+			Expect(responses[0].GetTransaction().GetContent()).To(Equal("Salary"))
+			Expect(responses[1].GetTransaction().GetContent()).To(Equal("Grocery"))
+			Expect(responses[2].GetSummary().GetTransactionCount()).To(Equal(int64(2)))
+			Expect(responses[2].GetSummary().GetTotalIncome()).To(Equal(int64(2000)))
+		})
+	})
+
+	Context("ValidateCSV", func() {
+		It("should report valid CSV with its transaction count", func() {
+			resp, err := client.ValidateCSV(ctx, &statementv1.ValidateCSVRequest{Input: inline(sampleCSV)})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.GetValid()).To(BeTrue())
+			Expect(resp.GetTransactionCount()).To(Equal(int64(3)))
+		})
+
+		It("should report the first problem of invalid CSV", func() {
+			resp, err := client.ValidateCSV(ctx, &statementv1.ValidateCSVRequest{Input: inline("date,amount,content\n2025/01/01,abc,Salary")})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.GetValid()).To(BeFalse())
+			Expect(resp.GetError()).To(ContainSubstring("failed to parse amount"))
+		})
+	})
+
+	Context("CodeForError", func() {
+		It("should map domain errors to gRPC codes", func() {
+			Expect(grpcapi.CodeForError(domain.NewValidationError("bad", nil))).To(Equal(codes.InvalidArgument))
+			Expect(grpcapi.CodeForError(domain.NewParseError("bad", nil))).To(Equal(codes.InvalidArgument))
+			Expect(grpcapi.CodeForError(domain.NewNotFoundError("thing"))).To(Equal(codes.NotFound))
+			Expect(grpcapi.CodeForError(domain.NewIOError("bad", context.Canceled))).To(Equal(codes.Canceled))
+			Expect(grpcapi.CodeForError(errors.New("other"))).To(Equal(codes.Internal))
+			Expect(grpcapi.CodeForError(domain.NewParseError("bad", in.ErrDecompressedTooLarge))).To(Equal(codes.ResourceExhausted))
+		})
+	})
+})
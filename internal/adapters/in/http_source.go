@@ -0,0 +1,139 @@
+package in
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultHTTPMaxRetries = 3
+	DefaultHTTPBackoff    = 500 * time.Millisecond
+	DefaultHTTPMaxBytes   = 256 << 20
+)
+
+// HTTPSource downloads CSV data over http:// and https://. Requests are bound to the
+// context passed to Open, so the caller's deadline covers the whole download; transient failures (network errors, 429 and 5xx responses)
+// are retried with exponential backoff and bodies larger than MaxBytes are rejected.
+type HTTPSource struct {
+	Client     *http.Client
+	Headers    http.Header
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBytes   int64
+}
+
+func NewHTTPSource() *HTTPSource {
+	return &HTTPSource{
+		Client:     &http.Client{},
+		Headers:    http.Header{},
+		MaxRetries: DefaultHTTPMaxRetries,
+		Backoff:    DefaultHTTPBackoff,
+		MaxBytes:   DefaultHTTPMaxBytes,
+	}
+}
+
+func (s *HTTPSource) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	var lastErr error
+	for attempt := 0; attempt <= s.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, s.Backoff<<(attempt-1)); err != nil {
+				return nil, err
+			}
+		}
+
+		body, retry, err := s.fetch(ctx, uri)
+		if err == nil {
+			return body, nil
+		}
+		if !retry || ctx.Err() != nil {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("giving up after %d attempts: %w", s.MaxRetries+1, lastErr)
+}
+
+func (s *HTTPSource) fetch(ctx context.Context, uri string) (io.ReadCloser, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	for key, values := range s.Headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		err := fmt.Errorf("GET %s: unexpected status %s", uri, resp.Status)
+		if resp.StatusCode == http.StatusNotFound {
+			err = fmt.Errorf("GET %s: %w", uri, fs.ErrNotExist)
+		}
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return nil, retry, err
+	}
+
+	if s.MaxBytes > 0 && resp.ContentLength > s.MaxBytes {
+		resp.Body.Close()
+		return nil, false, fmt.Errorf("GET %s: response of %d bytes exceeds limit of %d bytes", uri, resp.ContentLength, s.MaxBytes)
+	}
+
+	if s.MaxBytes > 0 {
+		return &limitedReadCloser{ReadCloser: resp.Body, remaining: s.MaxBytes, limit: s.MaxBytes}, false, nil
+	}
+	return resp.Body, false, nil
+}
+
+func (s *HTTPSource) client() *http.Client {
+	if s.Client == nil {
+		return http.DefaultClient
+	}
+	return s.Client
+}
+
+// limitedReadCloser fails instead of silently truncating bodies over the limit
+type limitedReadCloser struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (l *limitedReadCloser) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// Probe for one more byte to tell an exact fit from an oversized body
+		var probe [1]byte
+		if n, _ := l.ReadCloser.Read(probe[:]); n > 0 {
+			return 0, fmt.Errorf("response exceeds limit of %d bytes", l.limit)
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
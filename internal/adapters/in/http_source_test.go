@@ -0,0 +1,209 @@
+package in_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/in"
+)
+
+const httpCSV = "date,amount,content\n2025/01/05,2000,Salary\n"
+
+var _ = Describe("HTTPSource", func() {
+	var (
+		source *in.HTTPSource
+		ctx    context.Context
+	)
+
+	BeforeEach(func() {
+		source = in.NewHTTPSource()
+		source.Backoff = time.Millisecond
+		ctx = context.Background()
+	})
+
+	It("should download the CSV body", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, httpCSV)
+		}))
+		defer server.Close()
+
+		reader, err := source.Open(ctx, server.URL+"/transactions.csv")
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(httpCSV))
+	})
+
+	It("should send the configured headers", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+		}))
+		defer server.Close()
+
+		source.Headers.Set("Authorization", "Bearer secret")
+		reader, err := source.Open(ctx, server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("Bearer secret"))
+	})
+
+	It("should retry transient failures", func() {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = io.WriteString(w, httpCSV)
+		}))
+		defer server.Close()
+
+		reader, err := source.Open(ctx, server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		reader.Close()
+
+		Expect(calls.Load()).To(Equal(int32(3)))
+	})
+
+	It("should give up after the configured retries", func() {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		source.MaxRetries = 1
+		_, err := source.Open(ctx, server.URL)
+		Expect(err).To(MatchError(ContainSubstring("giving up after 2 attempts")))
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	It("should not retry client errors and report 404 as not found", func() {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+
+		_, err := source.Open(ctx, server.URL)
+		Expect(err).To(MatchError(fs.ErrNotExist))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	It("should stop when the context deadline passes", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := source.Open(timeoutCtx, server.URL)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	Context("with a size limit", func() {
+		BeforeEach(func() {
+			source.MaxBytes = 10
+		})
+
+		It("should reject responses with a larger Content-Length", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, httpCSV)
+			}))
+			defer server.Close()
+
+			_, err := source.Open(ctx, server.URL)
+			Expect(err).To(MatchError(ContainSubstring("exceeds limit")))
+		})
+
+		It("should fail while reading chunked responses over the limit", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.(http.Flusher).Flush()
+				_, _ = io.WriteString(w, httpCSV)
+			}))
+			defer server.Close()
+
+			reader, err := source.Open(ctx, server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer reader.Close()
+
+			_, err = io.ReadAll(reader)
+			Expect(err).To(MatchError(ContainSubstring("exceeds limit")))
+		})
+
+		It("should accept responses of exactly the limit", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.(http.Flusher).Flush()
+				_, _ = io.WriteString(w, strings.Repeat("x", 10))
+			}))
+			defer server.Close()
+
+			reader, err := source.Open(ctx, server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer reader.Close()
+
+			content, err := io.ReadAll(reader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(HaveLen(10))
+		})
+	})
+})
+
+var _ = Describe("SourceRegistry", func() {
+	It("should dispatch by URI scheme", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, httpCSV)
+		}))
+		defer server.Close()
+
+		tempFile, err := os.CreateTemp("", "registry_*.csv")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.Remove(tempFile.Name())
+		_, _ = tempFile.WriteString("local")
+		tempFile.Close()
+
+		registry := in.NewDefaultSourceRegistry(in.NewHTTPSource())
+		for uri, expected := range map[string]string{
+			server.URL:                  httpCSV,
+			tempFile.Name():             "local",
+			"file://" + tempFile.Name(): "local",
+		} {
+			reader, err := registry.Open(context.Background(), uri)
+			Expect(err).NotTo(HaveOccurred())
+			content, err := io.ReadAll(reader)
+			reader.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal(expected))
+		}
+	})
+
+	It("should reject unknown schemes", func() {
+		_, err := in.NewSourceRegistry().Open(context.Background(), "ftp://example.com/a.csv")
+		Expect(err).To(MatchError(ContainSubstring(`unsupported source scheme "ftp"`)))
+	})
+
+	It("should treat plain and Windows paths as files", func() {
+		Expect(in.Scheme("data/transactions.csv")).To(Equal("file"))
+		Expect(in.Scheme(`C:\data\transactions.csv`)).To(Equal("file"))
+		Expect(in.Scheme("HTTPS://example.com/a.csv")).To(Equal("https"))
+	})
+})
@@ -0,0 +1,13 @@
+package httpapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHTTPAPI(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HTTP API Suite")
+}
@@ -0,0 +1,202 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"mf-statement/internal/adapters/in"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+	"mf-statement/internal/util"
+)
+
+// DefaultMaxUploadBytes limits the size of uploaded files
+const DefaultMaxUploadBytes int64 = 32 << 20
+
+// StatusClientClosedRequest is reported when the client goes away before the statement is ready
+const StatusClientClosedRequest = 499
+
+// Server exposes statement generation over HTTP:
+//
+//	POST /statements?period=YYYYMM   upload as multipart "file" field or raw request body
+//	GET  /statements?period=YYYYMM&source=name.csv   input read from DataDir
+//	GET  /healthz
+//
+// A format parameter names the input format; without one it is detected from the
+// content and file name.
+type Server struct {
+	// DataDir is the directory GET sources are resolved in; GET is disabled when empty
+	DataDir        string
+	MaxUploadBytes int64
+	// MaxDecompressedBytes limits what a compressed upload may expand to
+	MaxDecompressedBytes int64
+	Source               usecase.Source
+	Parsers              *in.ParserRegistry
+	// Format is the input format of requests without a format parameter
+	Format string
+	Logger *util.Logger
+}
+
+func NewServer(dataDir string, logger *util.Logger) *Server {
+	return &Server{
+		DataDir:              dataDir,
+		MaxUploadBytes:       DefaultMaxUploadBytes,
+		MaxDecompressedBytes: in.DefaultMaxDecompressedBytes,
+		Source:               in.NewDecompressingSource(in.NewCSVFileSource()),
+		Parsers:              in.NewDefaultParserRegistry(),
+		Format:               in.FormatAuto,
+		Logger:               logger,
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /statements", s.handleUpload)
+	mux.HandleFunc("GET /statements", s.handleSource)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return mux
+}
+
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, s.MaxUploadBytes)
+
+	// The period of raw uploads comes from the query only: FormValue would consume a
+	// body sent as application/x-www-form-urlencoded
+	body := io.Reader(r.Body)
+	uri, period, format := "upload", r.URL.Query().Get("period"), r.URL.Query().Get("format")
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			s.writeError(w, domain.NewValidationError("missing multipart file field \"file\"", map[string]interface{}{
+				"error": err.Error(),
+			}))
+			return
+		}
+		defer file.Close()
+		body, uri, period, format = file, header.Filename, r.FormValue("period"), r.FormValue("format")
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		s.writeError(w, domain.NewIOError("failed to read upload", err))
+		return
+	}
+
+	source := in.NewDecompressingSource(in.NewBytesSource(content))
+	source.MaxBytes = s.MaxDecompressedBytes
+	s.generate(w, r, source, uri, period, format)
+}
+
+func (s *Server) handleSource(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	path, err := in.ResolveInDir(s.DataDir, query.Get("source"))
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	s.generate(w, r, s.Source, path, query.Get("period"), query.Get("format"))
+}
+
+func (s *Server) generate(w http.ResponseWriter, r *http.Request, source usecase.Source, uri, period, format string) {
+	year, month, display, err := util.ParseYYYYMM(period)
+	if err != nil {
+		s.writeError(w, domain.NewValidationError("invalid period format", map[string]interface{}{
+			"period": period,
+			"error":  err.Error(),
+		}))
+		return
+	}
+
+	if format == "" {
+		format = s.Format
+	}
+	inputParser, err := s.Parsers.Parser(format)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	writer := &output.StatementCapture{}
+	transactionService := usecase.NewTransactionService(source, inputParser)
+	statementService := usecase.NewStatementService(transactionService, writer)
+
+	if err := statementService.GenerateMonthlyStatement(r.Context(), uri, display, year, month); err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, writer.Statement)
+}
+
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Type    domain.ErrorType `json:"type"`
+	Message string           `json:"message"`
+}
+
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	status := StatusForError(err)
+
+	body := errorBody{Type: domain.ErrorTypeInternal, Message: err.Error()}
+	var domainErr domain.DomainError
+	if errors.As(err, &domainErr) {
+		body.Type = domainErr.Type
+	}
+
+	if s.Logger != nil {
+		s.Logger.Warn("Request failed", "status", status, "error", err)
+	}
+	writeJSON(w, status, errorResponse{Error: body})
+}
+
+// StatusForError maps domain error types onto HTTP status codes
+func StatusForError(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.As(err, &maxBytesErr), errors.Is(err, in.ErrDecompressedTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, fs.ErrNotExist):
+		return http.StatusNotFound
+	}
+
+	var domainErr domain.DomainError
+	if !errors.As(err, &domainErr) {
+		return http.StatusInternalServerError
+	}
+
+	switch domainErr.Type {
+	case domain.ErrorTypeValidation:
+		return http.StatusBadRequest
+	case domain.ErrorTypeNotFound:
+		return http.StatusNotFound
+	case domain.ErrorTypeParse:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
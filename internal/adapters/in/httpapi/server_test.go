@@ -0,0 +1,232 @@
+package httpapi_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/in/httpapi"
+	"mf-statement/internal/domain"
+)
+
+const sampleCSV = `date,amount,content
+2025/01/05,2000,Salary
+2025/01/09,-300,Grocery
+2025/02/01,999,Next Month
+`
+
+var _ = Describe("Server", func() {
+	var (
+		tempDir string
+		server  *httptest.Server
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "httpapi_test_*")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(tempDir, "transactions.csv"), []byte(sampleCSV), 0644)).To(Succeed())
+
+		server = httptest.NewServer(httpapi.NewServer(tempDir, nil).Handler())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(tempDir)
+	})
+
+	decodeStatement := func(resp *http.Response) domain.Statement {
+		defer resp.Body.Close()
+		var statement domain.Statement
+		Expect(json.NewDecoder(resp.Body).Decode(&statement)).To(Succeed())
+		return statement
+	}
+
+	decodeError := func(resp *http.Response) map[string]interface{} {
+		defer resp.Body.Close()
+		var body map[string]map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+		return body["error"]
+	}
+
+	Context("POST /statements", func() {
+		It("should generate a statement from a multipart upload", func() {
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			Expect(form.WriteField("period", "202501")).To(Succeed())
+			part, err := form.CreateFormFile("file", "transactions.csv")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = part.Write([]byte(sampleCSV))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(form.Close()).To(Succeed())
+
+			resp, err := http.Post(server.URL+"/statements", form.FormDataContentType(), &body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			statement := decodeStatement(resp)
+			Expect(statement.Period).To(Equal("2025/01"))
+			Expect(statement.TotalIncome).To(Equal(int64(2000)))
+			Expect(statement.TotalExpenditure).To(Equal(int64(-300)))
+			Expect(statement.Transactions).To(HaveLen(2))
+		})
+
+		It("should accept a raw CSV body", func() {
+			resp, err := http.Post(server.URL+"/statements?period=202502", "text/csv", strings.NewReader(sampleCSV))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(decodeStatement(resp).TotalIncome).To(Equal(int64(999)))
+		})
+
+		It("should read the period of a raw upload from the query without consuming a form-encoded body", func() {
+			resp, err := http.Post(server.URL+"/statements?period=202502", "application/x-www-form-urlencoded", strings.NewReader(sampleCSV))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			statement := decodeStatement(resp)
+			Expect(statement.TotalIncome).To(Equal(int64(999)))
+			Expect(statement.Transactions).NotTo(BeEmpty())
+		})
+
+		It("should detect the format of an upload from its content", func() {
+			ofx := "OFXHEADER:100\nDATA:OFXSGML\n\n<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>USD<BANKTRANLIST>" +
+				"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20250112<TRNAMT>-4.25<FITID>1<NAME>Bakery</STMTTRN>" +
+				"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>"
+			resp, err := http.Post(server.URL+"/statements?period=202501", "application/octet-stream", strings.NewReader(ofx))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(decodeStatement(resp).TotalExpenditure).To(Equal(int64(-425)))
+		})
+
+		It("should parse an upload in the format parameter", func() {
+			tsv := strings.ReplaceAll(sampleCSV, ",", "\t")
+			resp, err := http.Post(server.URL+"/statements?period=202501&format=tsv", "text/tab-separated-values", strings.NewReader(tsv))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(decodeStatement(resp).TotalIncome).To(Equal(int64(2000)))
+		})
+
+		It("should return 400 for an unknown format", func() {
+			resp, err := http.Post(server.URL+"/statements?period=202501&format=pdf", "text/csv", strings.NewReader(sampleCSV))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(decodeError(resp)["type"]).To(Equal("validation"))
+		})
+
+		It("should return 422 for malformed CSV", func() {
+			resp, err := http.Post(server.URL+"/statements?period=202501", "text/csv", strings.NewReader("date,amount,content\n2025/01/01,abc,Salary"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+			Expect(decodeError(resp)["type"]).To(Equal("parse"))
+		})
+
+		It("should return 413 when the upload is too large", func() {
+			limited := httpapi.NewServer(tempDir, nil)
+			limited.MaxUploadBytes = 16
+			limitedServer := httptest.NewServer(limited.Handler())
+			defer limitedServer.Close()
+
+			resp, err := http.Post(limitedServer.URL+"/statements?period=202501", "text/csv", strings.NewReader(sampleCSV))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+		})
+		It("should return 413 when a compressed upload expands past the limit", func() {
+			limited := httpapi.NewServer(tempDir, nil)
+			limited.MaxDecompressedBytes = 1 << 10
+			limitedServer := httptest.NewServer(limited.Handler())
+			defer limitedServer.Close()
+
+			var bomb bytes.Buffer
+			gz := gzip.NewWriter(&bomb)
+			_, err := gz.Write([]byte(sampleCSV + strings.Repeat("2025/01/10,-1,Coffee\n", 1<<12)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gz.Close()).To(Succeed())
+			Expect(int64(bomb.Len())).To(BeNumerically("<", limited.MaxUploadBytes))
+
+			resp, err := http.Post(limitedServer.URL+"/statements?period=202501", "application/gzip", &bomb)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+		})
+	})
+
+	Context("GET /statements", func() {
+		It("should generate a statement from a data directory source", func() {
+			resp, err := http.Get(server.URL + "/statements?period=202501&source=transactions.csv")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(decodeStatement(resp).Transactions).To(HaveLen(2))
+		})
+
+		It("should return 400 for an invalid period", func() {
+			resp, err := http.Get(server.URL + "/statements?period=2025-01&source=transactions.csv")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(decodeError(resp)["type"]).To(Equal("validation"))
+		})
+
+		It("should return 404 for a missing source", func() {
+			resp, err := http.Get(server.URL + "/statements?period=202501&source=missing.csv")
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should reject sources outside the data directory", func() {
+			resp, err := http.Get(server.URL + "/statements?period=202501&source=../etc/passwd")
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 404 when no data directory is configured", func() {
+			noData := httptest.NewServer(httpapi.NewServer("", nil).Handler())
+			defer noData.Close()
+
+			resp, err := http.Get(noData.URL + "/statements?period=202501&source=transactions.csv")
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("StatusForError", func() {
+		It("should map domain error types to status codes", func() {
+			Expect(httpapi.StatusForError(domain.NewValidationError("bad", nil))).To(Equal(http.StatusBadRequest))
+			Expect(httpapi.StatusForError(domain.NewNotFoundError("thing"))).To(Equal(http.StatusNotFound))
+			Expect(httpapi.StatusForError(domain.NewParseError("bad", nil))).To(Equal(http.StatusUnprocessableEntity))
+			Expect(httpapi.StatusForError(domain.NewIOError("bad", nil))).To(Equal(http.StatusInternalServerError))
+			Expect(httpapi.StatusForError(domain.NewInternalError("bad", nil))).To(Equal(http.StatusInternalServerError))
+			Expect(httpapi.StatusForError(errors.New("other"))).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should map cancellation and timeouts", func() {
+			Expect(httpapi.StatusForError(domain.NewParseError("failed to parse CSV", context.Canceled))).To(Equal(httpapi.StatusClientClosedRequest))
+			Expect(httpapi.StatusForError(context.DeadlineExceeded)).To(Equal(http.StatusGatewayTimeout))
+		})
+	})
+})
@@ -0,0 +1,228 @@
+package in
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// FormatAuto selects the format of each input by detection
+const FormatAuto = "auto"
+
+// sniffSize is how much of an input is inspected to detect its format
+const sniffSize = 512
+
+// ParserFactory creates the parser of an input format
+type ParserFactory func() usecase.Parser
+
+// InputFormat describes how an input format is recognised and parsed
+type InputFormat struct {
+	Name        string
+	Aliases     []string
+	Description string
+	// Extensions are the lower-case file extensions of the format, e.g. ".ofx"
+	Extensions []string
+	// Sniff reports whether the start of an input is in this format; formats
+	// without one are only recognised by name or extension
+	Sniff func(header []byte) bool
+	New   ParserFactory
+}
+
+// ParserRegistry maps format names to parsers and detects the format of inputs.
+// As a parser it detects each input's format: content sniffers are tried in
+// registration order, then the file extension, and anything else is read in the
+// fallback format.
+type ParserRegistry struct {
+	formats  []InputFormat
+	fallback string
+}
+
+func NewParserRegistry(fallback string) *ParserRegistry {
+	return &ParserRegistry{fallback: fallback}
+}
+
+// NewDefaultParserRegistry supports every input format, falling back to CSV
+func NewDefaultParserRegistry() *ParserRegistry {
+	r := NewParserRegistry("csv")
+	r.Register(InputFormat{
+		Name: "xlsx", Description: "Excel workbooks", Extensions: []string{".xlsx"},
+		Sniff: parser.LooksLikeXLSX, New: func() usecase.Parser { return parser.NewXLSX() },
+	})
+	r.Register(InputFormat{
+		Name: "ofx", Aliases: []string{"qfx"}, Description: "OFX/QFX bank statements", Extensions: []string{".ofx", ".qfx"},
+		Sniff: parser.LooksLikeOFX, New: func() usecase.Parser { return parser.NewOFX() },
+	})
+	r.Register(InputFormat{
+		Name: "qif", Description: "Quicken Interchange Format", Extensions: []string{".qif"},
+		Sniff: parser.LooksLikeQIF, New: func() usecase.Parser { return parser.NewQIF() },
+	})
+	r.Register(InputFormat{
+		Name: "camt053", Aliases: []string{"camt.053", "camt"}, Description: "ISO 20022 camt.053 statements",
+		Sniff: parser.LooksLikeCamt053, New: func() usecase.Parser { return parser.NewCamt053() },
+	})
+	r.Register(InputFormat{
+		Name: "mt940", Description: "SWIFT MT940 statements", Extensions: []string{".sta", ".mt940", ".940"},
+		Sniff: parser.LooksLikeMT940, New: func() usecase.Parser { return parser.NewMT940() },
+	})
+	r.Register(InputFormat{
+		Name: "json", Aliases: []string{"ndjson", "jsonl"}, Description: "JSON arrays and newline-delimited JSON records",
+		Extensions: []string{".json", ".ndjson", ".jsonl"},
+		Sniff:      parser.LooksLikeJSON, New: func() usecase.Parser { return parser.NewJSON() },
+	})
+	r.Register(InputFormat{
+		Name: "tsv", Description: "Tab-separated date, amount, content", Extensions: []string{".tsv", ".tab"},
+		Sniff: parser.LooksLikeTSV, New: func() usecase.Parser { return parser.NewTSV() },
+	})
+	r.Register(InputFormat{
+		Name: "csv", Description: "Comma-separated date, amount, content", Extensions: []string{".csv", ".txt"},
+		New: func() usecase.Parser { return parser.NewCSV() },
+	})
+	return r
+}
+
+// Register adds a format, replacing any previous registration of the same name
+func (r *ParserRegistry) Register(format InputFormat) {
+	for i, registered := range r.formats {
+		if registered.Name == format.Name {
+			r.formats[i] = format
+			return
+		}
+	}
+	r.formats = append(r.formats, format)
+}
+
+// SetParser makes a registered format use the given parser, e.g. one configured by flags
+func (r *ParserRegistry) SetParser(name string, p usecase.Parser) {
+	if format, ok := r.Lookup(name); ok {
+		format.New = func() usecase.Parser { return p }
+		r.Register(format)
+	}
+}
+
+// Formats returns the registered formats in registration order
+func (r *ParserRegistry) Formats() []InputFormat {
+	return append([]InputFormat(nil), r.formats...)
+}
+
+// Fallback returns the name of the format inputs are read in when none is detected
+func (r *ParserRegistry) Fallback() string {
+	return r.fallback
+}
+
+// Names returns "auto" followed by the registered format names
+func (r *ParserRegistry) Names() []string {
+	names := []string{FormatAuto}
+	for _, format := range r.formats {
+		names = append(names, format.Name)
+	}
+	return names
+}
+
+// Lookup finds a format by name or alias, ignoring case
+func (r *ParserRegistry) Lookup(name string) (InputFormat, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, format := range r.formats {
+		if format.Name == name {
+			return format, true
+		}
+		for _, alias := range format.Aliases {
+			if alias == name {
+				return format, true
+			}
+		}
+	}
+	return InputFormat{}, false
+}
+
+// Parser returns the parser of the named format; "auto" returns the registry itself
+func (r *ParserRegistry) Parser(name string) (usecase.Parser, error) {
+	if name == "" || strings.EqualFold(name, FormatAuto) {
+		return r, nil
+	}
+	format, ok := r.Lookup(name)
+	if !ok {
+		return nil, domain.NewValidationError("unsupported input format", map[string]interface{}{
+			"format":  name,
+			"allowed": r.Names(),
+		})
+	}
+	return format.New(), nil
+}
+
+// Detect returns the format of an input from the start of its content and its URI
+func (r *ParserRegistry) Detect(uri string, header []byte) (InputFormat, bool) {
+	for _, format := range r.formats {
+		if format.Sniff != nil && format.Sniff(header) {
+			return format, true
+		}
+	}
+	if ext := Extension(uri); ext != "" {
+		for _, format := range r.formats {
+			for _, candidate := range format.Extensions {
+				if candidate == ext {
+					return format, true
+				}
+			}
+		}
+	}
+	return r.Lookup(r.fallback)
+}
+
+func (r *ParserRegistry) Parse(ctx context.Context, reader io.Reader) ([]domain.Transaction, error) {
+	return r.ParseNamed(ctx, "", reader)
+}
+
+// ParseNamed detects the format of the input at uri and parses it
+func (r *ParserRegistry) ParseNamed(ctx context.Context, uri string, reader io.Reader) ([]domain.Transaction, error) {
+	buffered := bufio.NewReaderSize(reader, sniffSize)
+	// A short input is sniffed as far as it goes
+	header, _ := buffered.Peek(sniffSize)
+
+	format, ok := r.Detect(uri, header)
+	if !ok {
+		return nil, domain.NewValidationError("input format not recognised", map[string]interface{}{"uri": uri})
+	}
+	return format.New().Parse(ctx, buffered)
+}
+
+// StreamNamed detects the format of the input at uri and streams it when its parser
+// can; formats read as a whole, such as XLSX, report a validation error
+func (r *ParserRegistry) StreamNamed(ctx context.Context, uri string, reader io.Reader, emit func(domain.Transaction) error) error {
+	buffered := bufio.NewReaderSize(reader, sniffSize)
+	header, _ := buffered.Peek(sniffSize)
+
+	format, ok := r.Detect(uri, header)
+	if !ok {
+		return domain.NewValidationError("input format not recognised", map[string]interface{}{"uri": uri})
+	}
+	streaming, ok := format.New().(usecase.StreamingParser)
+	if !ok {
+		return domain.NewValidationError(fmt.Sprintf("%s inputs cannot be streamed", format.Name), map[string]interface{}{"uri": uri})
+	}
+	return streaming.Stream(ctx, buffered, emit)
+}
+
+// compressionExtensions are looked through, so statement.ofx.gz is an OFX file
+var compressionExtensions = map[string]bool{".gz": true, ".bz2": true, ".zst": true}
+
+// Extension returns the lower-case file extension of a path or URL, ignoring
+// compression suffixes
+func Extension(uri string) string {
+	name := uri
+	if u, err := url.Parse(uri); err == nil && len(u.Scheme) > 1 {
+		name = u.Path
+	}
+	ext := strings.ToLower(path.Ext(name))
+	if compressionExtensions[ext] {
+		ext = strings.ToLower(path.Ext(strings.TrimSuffix(name, path.Ext(name))))
+	}
+	return ext
+}
@@ -0,0 +1,94 @@
+package in_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/in"
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+const registryOFX = `OFXHEADER:100
+DATA:OFXSGML
+
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>USD<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20250109<TRNAMT>-3.50<FITID>1<NAME>Coffee</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`
+
+var _ = Describe("ParserRegistry", func() {
+	var registry *in.ParserRegistry
+
+	BeforeEach(func() {
+		registry = in.NewDefaultParserRegistry()
+	})
+
+	DescribeTable("should detect the format from the content, then the extension",
+		func(uri, content, expected string) {
+			format, ok := registry.Detect(uri, []byte(content))
+
+			Expect(ok).To(BeTrue())
+			Expect(format.Name).To(Equal(expected))
+		},
+		Entry("OFX content under another extension", "export.txt", registryOFX, "ofx"),
+		Entry("JSON content", "-", `[{"date": "2025/01/01"}]`, "json"),
+		Entry("tab-separated header", "export", "date\tamount\tcontent\n", "tsv"),
+		Entry("extension only", "statement.sta", "", "mt940"),
+		Entry("extension behind compression", "statement.QFX.gz", "", "ofx"),
+		Entry("extension of a URL path", "https://bank.example/export.qif?token=abc", "", "qif"),
+		Entry("fallback", "transactions", "date,amount,content\n", "csv"),
+	)
+
+	DescribeTable("should parse every supported format in auto mode",
+		func(uri, content string, expected domain.Transaction) {
+			transactions, err := registry.ParseNamed(context.Background(), uri, strings.NewReader(content))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(ContainElement(expected))
+		},
+		Entry("OFX", "export.txt", registryOFX, domain.Transaction{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -350, Content: "Coffee", ID: "1"}),
+		Entry("TSV", "export.tsv", "date\tamount\tcontent\n2025/01/09\t-300\tGrocery\n", domain.Transaction{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -300, Content: "Grocery"}),
+		Entry("CSV", "export.csv", "date,amount,content\n2025/01/09,-300,Grocery\n", domain.Transaction{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -300, Content: "Grocery"}),
+	)
+
+	It("should look formats up by name or alias", func() {
+		p, err := registry.Parser("QFX")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(BeAssignableToTypeOf(&parser.OFXParser{}))
+
+		p, err = registry.Parser(in.FormatAuto)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(BeIdenticalTo(registry))
+
+		_, err = registry.Parser("pdf")
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+
+	It("should use parsers set after registration", func() {
+		mt940 := &parser.MT940Parser{Reconcile: true}
+		registry.SetParser("mt940", mt940)
+
+		p, err := registry.Parser("mt940")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(BeIdenticalTo(mt940))
+	})
+
+	It("should receive the input URI from the transaction service", func() {
+		registry := in.NewParserRegistry("csv")
+		registry.Register(in.InputFormat{Name: "csv", New: func() usecase.Parser { return parser.NewCSV() }})
+		registry.Register(in.InputFormat{Name: "tsv", Extensions: []string{".tsv"}, New: func() usecase.Parser { return parser.NewTSV() }})
+		source := in.NewBytesSource([]byte("date\tamount\tcontent\n2025/01/09\t-300\tGrocery\n"))
+
+		transactions, err := usecase.NewTransactionService(source, registry).GetAllTransactions(context.Background(), "export.tsv")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,75 @@
+package in
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/domain"
+)
+
+// S3Source reads CSV objects addressed as s3://bucket/key
+type S3Source struct {
+	Client *minio.Client
+}
+
+func NewS3Source(client *minio.Client) *S3Source {
+	return &S3Source{Client: client}
+}
+
+func (s *S3Source) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	bucket, key, err := objectstore.ParseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := s.Client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject is lazy; Stat surfaces missing objects and auth errors before parsing starts
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if objectstore.IsNotFound(err) {
+			return nil, fmt.Errorf("%s: %w", uri, fs.ErrNotExist)
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+// LazyS3Source creates its client on the first s3:// input, so an S3 configuration
+// that cannot be used only fails the runs that read from S3
+type LazyS3Source struct {
+	Config objectstore.Config
+
+	once   sync.Once
+	source *S3Source
+	err    error
+}
+
+func NewLazyS3Source(cfg objectstore.Config) *LazyS3Source {
+	return &LazyS3Source{Config: cfg}
+}
+
+func (s *LazyS3Source) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	s.once.Do(func() {
+		client, err := objectstore.NewClient(s.Config)
+		if err != nil {
+			s.err = domain.NewValidationError("invalid S3 configuration", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+		s.source = NewS3Source(client)
+	})
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.source.Open(ctx, uri)
+}
@@ -0,0 +1,75 @@
+package in_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/in"
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/adapters/objectstore/objectstoretest"
+)
+
+var _ = Describe("S3Source", func() {
+	var (
+		server *objectstoretest.Server
+		source *in.S3Source
+	)
+
+	BeforeEach(func() {
+		server = objectstoretest.NewServer()
+		DeferCleanup(server.Close)
+
+		client, err := objectstore.NewClient(server.Config())
+		Expect(err).NotTo(HaveOccurred())
+		source = in.NewS3Source(client)
+	})
+
+	It("should read the object", func() {
+		server.Put("exports", "2025/transactions.csv", []byte(httpCSV))
+
+		reader, err := source.Open(context.Background(), "s3://exports/2025/transactions.csv")
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(httpCSV))
+	})
+
+	It("should report missing objects as not found", func() {
+		_, err := source.Open(context.Background(), "s3://exports/missing.csv")
+		Expect(err).To(MatchError(fs.ErrNotExist))
+	})
+
+	It("should reject URIs without a key", func() {
+		_, err := source.Open(context.Background(), "s3://exports")
+		Expect(err).To(MatchError(ContainSubstring("s3://bucket/key")))
+	})
+})
+
+var _ = Describe("LazyS3Source", func() {
+	It("should create the client on the first read", func() {
+		server := objectstoretest.NewServer()
+		DeferCleanup(server.Close)
+		server.Put("exports", "transactions.csv", []byte(httpCSV))
+
+		reader, err := in.NewLazyS3Source(server.Config()).Open(context.Background(), "s3://exports/transactions.csv")
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(httpCSV))
+	})
+
+	It("should report an unusable configuration when an object is read", func() {
+		source := in.NewLazyS3Source(objectstore.Config{Endpoint: "ftp://storage"})
+
+		_, err := source.Open(context.Background(), "s3://exports/transactions.csv")
+		Expect(err).To(MatchError(ContainSubstring("invalid S3 configuration")))
+	})
+})
@@ -0,0 +1,60 @@
+package in
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"mf-statement/internal/usecase"
+)
+
+// SourceRegistry dispatches Open to the source registered for the URI scheme.
+// URIs without a scheme, and file:// URIs, go to the local file source; "-" reads stdin.
+type SourceRegistry struct {
+	sources map[string]usecase.Source
+}
+
+func NewSourceRegistry() *SourceRegistry {
+	r := &SourceRegistry{sources: map[string]usecase.Source{}}
+	r.Register("file", NewCSVFileSource())
+	r.Register(StdinScheme, NewStdinSource(os.Stdin))
+	return r
+}
+
+// NewDefaultSourceRegistry supports local files and http(s) URLs
+func NewDefaultSourceRegistry(httpSource *HTTPSource) *SourceRegistry {
+	r := NewSourceRegistry()
+	r.Register("http", httpSource)
+	r.Register("https", httpSource)
+	return r
+}
+
+// Register sets the source for a scheme, replacing any previous registration
+func (r *SourceRegistry) Register(scheme string, source usecase.Source) {
+	r.sources[strings.ToLower(scheme)] = source
+}
+
+func (r *SourceRegistry) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	scheme := Scheme(uri)
+	source, ok := r.sources[scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported source scheme %q", scheme)
+	}
+	return source.Open(ctx, uri)
+}
+
+// Scheme returns the lower-cased URI scheme, "file" for plain paths and "stdin" for "-"
+func Scheme(uri string) string {
+	if uri == StdinURI {
+		return StdinScheme
+	}
+	u, err := url.Parse(uri)
+	// Single letters are Windows drive letters rather than schemes
+	if err != nil || len(u.Scheme) < 2 {
+		return "file"
+	}
+	return strings.ToLower(u.Scheme)
+}
@@ -0,0 +1,26 @@
+package in
+
+import (
+	"context"
+	"io"
+)
+
+const (
+	// StdinURI is the --csv value that reads standard input
+	StdinURI    = "-"
+	StdinScheme = "stdin"
+)
+
+// StdinSource serves standard input; it can only be consumed once
+type StdinSource struct {
+	Stdin io.Reader
+}
+
+func NewStdinSource(stdin io.Reader) *StdinSource {
+	return &StdinSource{Stdin: stdin}
+}
+
+func (s *StdinSource) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	// Closing the statement input must not close the process's stdin
+	return io.NopCloser(s.Stdin), nil
+}
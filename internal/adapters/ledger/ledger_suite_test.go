@@ -0,0 +1,13 @@
+package ledger_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLedger(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ledger Suite")
+}
@@ -0,0 +1,179 @@
+// Package ledger keeps imported transactions in a local SQLite file, so statements can
+// be generated from everything imported over time without reparsing the exports
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// dateLayout stores dates as sortable text, so period queries use the date index
+const dateLayout = "2006-01-02"
+
+const schema = `
+CREATE TABLE IF NOT EXISTS files (
+	id          INTEGER PRIMARY KEY,
+	uri         TEXT NOT NULL,
+	hash        TEXT NOT NULL UNIQUE,
+	imported_at TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS transactions (
+	id          INTEGER PRIMARY KEY,
+	fingerprint TEXT NOT NULL UNIQUE,
+	date        TEXT NOT NULL,
+	amount      INTEGER NOT NULL,
+	content     TEXT NOT NULL,
+	category    TEXT NOT NULL DEFAULT '',
+	external_id TEXT NOT NULL DEFAULT '',
+	source      TEXT NOT NULL DEFAULT '',
+	file_id     INTEGER NOT NULL REFERENCES files(id)
+);
+CREATE INDEX IF NOT EXISTS transactions_date ON transactions(date);
+`
+
+// Store implements usecase.TransactionStore and usecase.TransactionService on a SQLite
+// file. The URI argument of the TransactionService methods is ignored; the store holds
+// the transactions of every import.
+type Store struct {
+	DB        *sql.DB
+	Validator usecase.Validator
+}
+
+// Open opens the store at path, creating the file and its tables when missing
+func Open(ctx context.Context, path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, domain.NewIOError("failed to open transaction store", err)
+	}
+	// A single connection serializes writers instead of failing with SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		db.Close()
+		return nil, domain.NewIOError(fmt.Sprintf("failed to initialize transaction store %s", path), err)
+	}
+	return &Store{DB: db, Validator: usecase.NewPeriodValidator()}, nil
+}
+
+func (s *Store) Close() error {
+	return s.DB.Close()
+}
+
+func (s *Store) HasFile(ctx context.Context, hash string) (bool, error) {
+	var count int
+	if err := s.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM files WHERE hash = ?", hash).Scan(&count); err != nil {
+		return false, domain.NewIOError("failed to look up imported file", err)
+	}
+	return count > 0, nil
+}
+
+func (s *Store) Add(ctx context.Context, file usecase.ImportedFile, transactions []domain.Transaction, fingerprints []string) (int, error) {
+	if len(fingerprints) != len(transactions) {
+		return 0, domain.NewValidationError("every transaction needs a fingerprint", map[string]interface{}{
+			"transactions": len(transactions),
+			"fingerprints": len(fingerprints),
+		})
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, domain.NewIOError("failed to start import", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "INSERT INTO files (uri, hash, imported_at) VALUES (?, ?, ?)",
+		file.URI, file.Hash, file.ImportedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, domain.NewIOError("failed to record imported file", err)
+	}
+	fileID, err := result.LastInsertId()
+	if err != nil {
+		return 0, domain.NewIOError("failed to record imported file", err)
+	}
+
+	insert, err := tx.PrepareContext(ctx, `INSERT INTO transactions (fingerprint, date, amount, content, category, external_id, source, file_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (fingerprint) DO NOTHING`)
+	if err != nil {
+		return 0, domain.NewIOError("failed to prepare import", err)
+	}
+	defer insert.Close()
+
+	added := 0
+	for i, t := range transactions {
+		result, err := insert.ExecContext(ctx, fingerprints[i], t.Date.Format(dateLayout), t.Amount, t.Content, t.Category, t.ID, t.Source, fileID)
+		if err != nil {
+			return 0, domain.NewIOError("failed to store transaction", err)
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			added += int(n)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, domain.NewIOError("failed to commit import", err)
+	}
+	return added, nil
+}
+
+func (s *Store) GetAllTransactions(ctx context.Context, uri string) ([]domain.Transaction, error) {
+	return s.query(ctx, "")
+}
+
+func (s *Store) GetTransactionsByPeriod(ctx context.Context, uri string, year, month int) ([]domain.Transaction, error) {
+	if err := s.Validator.ValidatePeriod(year, month); err != nil {
+		return nil, err
+	}
+
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return s.query(ctx, "WHERE date >= ? AND date < ?", start.Format(dateLayout), start.AddDate(0, 1, 0).Format(dateLayout))
+}
+
+func (s *Store) GetTransactionsByDateRange(ctx context.Context, uri string, startDate, endDate time.Time) ([]domain.Transaction, error) {
+	return s.query(ctx, "WHERE date >= ? AND date <= ?", startDate.Format(dateLayout), endDate.Format(dateLayout))
+}
+
+func (s *Store) CalculateTotals(transactions []domain.Transaction) (totalIncome, totalExpenditure int64) {
+	for _, transaction := range transactions {
+		if transaction.IsIncome() {
+			totalIncome += transaction.Amount
+		} else if transaction.IsExpense() {
+			totalExpenditure += transaction.Amount
+		}
+	}
+	return totalIncome, totalExpenditure
+}
+
+// query returns the matching transactions newest first, in import order within a day
+func (s *Store) query(ctx context.Context, where string, args ...interface{}) ([]domain.Transaction, error) {
+	rows, err := s.DB.QueryContext(ctx, "SELECT date, amount, content, category, external_id, source FROM transactions "+where+" ORDER BY date DESC, id", args...)
+	if err != nil {
+		return nil, domain.NewIOError("failed to query transactions", err)
+	}
+	defer rows.Close()
+
+	var transactions []domain.Transaction
+	for rows.Next() {
+		var (
+			t    domain.Transaction
+			date string
+		)
+		if err := rows.Scan(&date, &t.Amount, &t.Content, &t.Category, &t.ID, &t.Source); err != nil {
+			return nil, domain.NewIOError("failed to read transaction", err)
+		}
+		if t.Date, err = time.Parse(dateLayout, date); err != nil {
+			return nil, domain.NewParseError(fmt.Sprintf("invalid stored date %q", date), err)
+		}
+		transactions = append(transactions, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.NewIOError("failed to read transactions", err)
+	}
+	return transactions, nil
+}
@@ -0,0 +1,92 @@
+package ledger_test
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/ledger"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		ctx   context.Context
+		path  string
+		store *ledger.Store
+	)
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		path = filepath.Join(GinkgoT().TempDir(), "ledger.db")
+
+		var err error
+		store, err = ledger.Open(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() { _ = store.Close() })
+
+		transactions := []domain.Transaction{
+			{Date: date(1, 5), Amount: 2000, Content: "Salary", Category: "income", Source: "january.csv"},
+			{Date: date(1, 9), Amount: -300, Content: "Coffee", ID: "FIT-1", Source: "january.csv"},
+			{Date: date(1, 9), Amount: -300, Content: "Coffee", ID: "FIT-2", Source: "january.csv"},
+			{Date: date(2, 1), Amount: -50, Content: "Bus", Source: "january.csv"},
+		}
+		added, err := store.Add(ctx, usecase.ImportedFile{URI: "january.csv", Hash: "jan", ImportedAt: date(2, 2)}, transactions, usecase.Fingerprints(transactions))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(added).To(Equal(4))
+	})
+
+	It("should return the transactions of a period newest first", func() {
+		transactions, err := store.GetTransactionsByPeriod(ctx, "", 2025, 1)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(3))
+		Expect(transactions[0]).To(Equal(domain.Transaction{Date: date(1, 9), Amount: -300, Content: "Coffee", ID: "FIT-1", Source: "january.csv"}))
+		Expect(transactions[1].ID).To(Equal("FIT-2"))
+		Expect(transactions[2].Category).To(Equal("income"))
+	})
+
+	It("should include both ends of a date range", func() {
+		transactions, err := store.GetTransactionsByDateRange(ctx, "", date(1, 9), date(2, 1))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(3))
+	})
+
+	It("should keep the transactions when reopened and skip stored fingerprints", func() {
+		Expect(store.Close()).To(Succeed())
+		reopened, err := ledger.Open(ctx, path)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() { _ = reopened.Close() })
+
+		imported, err := reopened.HasFile(ctx, "jan")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(imported).To(BeTrue())
+
+		overlap := []domain.Transaction{
+			{Date: date(2, 1), Amount: -50, Content: "Bus", Source: "february.csv"},
+			{Date: date(2, 3), Amount: -80, Content: "Books", Source: "february.csv"},
+		}
+		added, err := reopened.Add(ctx, usecase.ImportedFile{URI: "february.csv", Hash: "feb", ImportedAt: date(3, 1)}, overlap, usecase.Fingerprints(overlap))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(added).To(Equal(1))
+		all, err := reopened.GetAllTransactions(ctx, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(all).To(HaveLen(5))
+	})
+
+	It("should reject invalid periods", func() {
+		_, err := store.GetTransactionsByPeriod(ctx, "", 2025, 13)
+
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+})
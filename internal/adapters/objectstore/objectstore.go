@@ -0,0 +1,98 @@
+// Package objectstore holds the S3 client configuration shared by the s3:// source and output
+package objectstore
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	Scheme = "s3"
+
+	DefaultEndpoint = "https://s3.amazonaws.com"
+	DefaultRegion   = "us-east-1"
+)
+
+// Config describes how to reach an S3-compatible service. Empty keys fall back to the
+// AWS_*/MINIO_* environment variables and the shared AWS credentials file.
+type Config struct {
+	// Endpoint is the service URL, e.g. http://localhost:9000 for a local MinIO
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// PathStyle addresses buckets as endpoint/bucket/key instead of bucket.endpoint/key
+	PathStyle bool
+	Transport http.RoundTripper
+}
+
+// NewClient creates a client for the configured endpoint
+func NewClient(cfg Config) (*minio.Client, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("S3 endpoint must be an http:// or https:// URL: " + endpoint)
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	creds := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.EnvMinio{},
+		&credentials.FileAWSCredentials{},
+	})
+	if cfg.AccessKeyID != "" {
+		creds = credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken)
+	}
+
+	lookup := minio.BucketLookupAuto
+	if cfg.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	return minio.New(u.Host, &minio.Options{
+		Creds:        creds,
+		Secure:       u.Scheme == "https",
+		Transport:    cfg.Transport,
+		Region:       region,
+		BucketLookup: lookup,
+	})
+}
+
+// IsURI reports whether uri uses the s3:// scheme
+func IsURI(uri string) bool {
+	return strings.HasPrefix(strings.ToLower(uri), Scheme+"://")
+}
+
+// ParseURI splits s3://bucket/key into bucket and key
+func ParseURI(uri string) (bucket, key string, err error) {
+	if !IsURI(uri) {
+		return "", "", errors.New("not an s3:// URI: " + uri)
+	}
+	bucket, key, _ = strings.Cut(uri[len(Scheme+"://"):], "/")
+	if bucket == "" || key == "" {
+		return "", "", errors.New("S3 URI must be s3://bucket/key: " + uri)
+	}
+	return bucket, key, nil
+}
+
+// IsNotFound reports whether err is a missing bucket or object response
+func IsNotFound(err error) bool {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NoSuchBucket", "NotFound":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package objectstore_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/objectstore"
+)
+
+var _ = Describe("Objectstore", func() {
+	Context("ParseURI", func() {
+		It("should split bucket and key", func() {
+			bucket, key, err := objectstore.ParseURI("s3://exports/2025/01/transactions.csv")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bucket).To(Equal("exports"))
+			Expect(key).To(Equal("2025/01/transactions.csv"))
+		})
+
+		It("should reject URIs without bucket or key", func() {
+			for _, uri := range []string{"s3://", "s3://exports", "s3://exports/", "/tmp/transactions.csv"} {
+				_, _, err := objectstore.ParseURI(uri)
+				Expect(err).To(HaveOccurred(), uri)
+			}
+		})
+	})
+
+	Context("NewClient", func() {
+		It("should require an http or https endpoint", func() {
+			_, err := objectstore.NewClient(objectstore.Config{Endpoint: "localhost:9000"})
+			Expect(err).To(MatchError(ContainSubstring("http:// or https://")))
+		})
+
+		It("should default to AWS", func() {
+			client, err := objectstore.NewClient(objectstore.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.EndpointURL().Host).To(Equal("s3.amazonaws.com"))
+		})
+	})
+})
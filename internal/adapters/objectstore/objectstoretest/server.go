@@ -0,0 +1,113 @@
+// Package objectstoretest provides an in-memory S3 stand-in for tests
+package objectstoretest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mf-statement/internal/adapters/objectstore"
+)
+
+// Server implements the GET, HEAD and PUT object calls of the S3 API over TLS with
+// path-style addressing. Requests are not authenticated.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	headers map[string]http.Header
+}
+
+func NewServer() *Server {
+	s := &Server{objects: map[string][]byte{}, headers: map[string]http.Header{}}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Config returns a client configuration pointing at the server
+func (s *Server) Config() objectstore.Config {
+	return objectstore.Config{
+		Endpoint:        s.URL,
+		AccessKeyID:     "test",
+		SecretAccessKey: "testtesttest",
+		PathStyle:       true,
+		Transport:       s.Client().Transport,
+	}
+}
+
+// Put stores an object
+func (s *Server) Put(bucket, key string, content []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[bucket+"/"+key] = content
+}
+
+// Get returns a stored object
+func (s *Server) Get(bucket, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.objects[bucket+"/"+key]
+	return content, ok
+}
+
+// Header returns the request headers an object was uploaded with
+func (s *Server) Header(bucket, key string) http.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.headers[bucket+"/"+key]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		content, ok := s.objects[name]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", r.Method == http.MethodHead)
+			return
+		}
+		sum := md5.Sum(content)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	case http.MethodPut:
+		if _, exists := s.objects[name]; exists && r.Header.Get("If-None-Match") == "*" {
+			writeError(w, http.StatusPreconditionFailed, "PreconditionFailed", false)
+			return
+		}
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", false)
+			return
+		}
+		s.objects[name] = content
+		s.headers[name] = r.Header.Clone()
+		sum := md5.Sum(content)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+	default:
+		writeError(w, http.StatusNotImplemented, "NotImplemented", false)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code string, headOnly bool) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	if !headOnly {
+		fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+	}
+}
@@ -0,0 +1,187 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DefaultFilePerm is the permission used for output files when none is configured
+const DefaultFilePerm os.FileMode = 0644
+
+// link is os.Link, replaced in tests to simulate filesystems without hard links
+var link = os.Link
+
+// FileOptions controls how file-based writers create their output
+type FileOptions struct {
+	// Perm is the permission of the final file (DefaultFilePerm when zero)
+	Perm os.FileMode
+	// NoClobber makes the write fail instead of replacing an existing file
+	NoClobber bool
+}
+
+func (o FileOptions) perm() os.FileMode {
+	if o.Perm == 0 {
+		return DefaultFilePerm
+	}
+	return o.Perm
+}
+
+// AtomicFile is an output file that only becomes visible at its final path once
+// Commit succeeds. Data is written to a temporary file in the same directory,
+// synced to disk and renamed into place, so readers never observe a partial file.
+type AtomicFile struct {
+	path    string
+	options FileOptions
+	tmp     *os.File
+	done    bool
+}
+
+// CreateAtomic starts an atomic write to path
+func CreateAtomic(path string, options FileOptions) (*AtomicFile, error) {
+	if options.NoClobber {
+		if _, err := os.Lstat(path); err == nil {
+			return nil, fmt.Errorf("%s: %w", path, fs.ErrExist)
+		}
+	}
+
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &AtomicFile{path: path, options: options, tmp: tmp}, nil
+}
+
+func (f *AtomicFile) Write(p []byte) (int, error) {
+	return f.tmp.Write(p)
+}
+
+// Commit flushes the data to disk and moves the file to its final path
+func (f *AtomicFile) Commit() error {
+	if f.done {
+		return fmt.Errorf("%s: atomic file already closed", f.path)
+	}
+	f.done = true
+
+	if err := f.finish(); err != nil {
+		os.Remove(f.tmp.Name())
+		return err
+	}
+
+	syncDir(filepath.Dir(f.path))
+	return nil
+}
+
+// Close discards the temporary file unless Commit already succeeded, so it is
+// safe to defer right after CreateAtomic
+func (f *AtomicFile) Close() error {
+	if f.done {
+		return nil
+	}
+	f.done = true
+
+	f.tmp.Close()
+	return os.Remove(f.tmp.Name())
+}
+
+func (f *AtomicFile) finish() error {
+	if err := f.tmp.Sync(); err != nil {
+		f.tmp.Close()
+		return err
+	}
+	if err := f.tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.tmp.Name(), f.options.perm()); err != nil {
+		return err
+	}
+
+	if f.options.NoClobber {
+		return f.commitNoClobber()
+	}
+
+	return os.Rename(f.tmp.Name(), f.path)
+}
+
+// commitNoClobber moves the temporary file to a path that must not exist. Link fails if
+// the destination appeared since CreateAtomic, unlike Rename. Where hard links are not
+// supported the data is copied into a file created exclusively instead, which readers
+// may observe while it is being written.
+func (f *AtomicFile) commitNoClobber() error {
+	err := link(f.tmp.Name(), f.path)
+	if errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	if err != nil {
+		if err := copyExclusive(f.tmp.Name(), f.path, f.options.perm()); err != nil {
+			return err
+		}
+	}
+	return os.Remove(f.tmp.Name())
+}
+
+// copyExclusive copies src to a new file dst, failing if dst exists and removing it
+// again when the copy fails
+func copyExclusive(src, dst string, perm os.FileMode) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			out.Close()
+			os.Remove(dst)
+		}
+	}()
+
+	if _, err = io.Copy(out, in); err != nil {
+		return err
+	}
+	if err = out.Sync(); err != nil {
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	// OpenFile applies the umask
+	return os.Chmod(dst, perm)
+}
+
+// writeFileAtomic writes the output produced by write to path atomically
+func writeFileAtomic(path string, options FileOptions, write func(w io.Writer) error) error {
+	file, err := CreateAtomic(path, options)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := write(file); err != nil {
+		return err
+	}
+
+	return file.Commit()
+}
+
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	defer d.Close()
+	_ = d.Sync()
+}
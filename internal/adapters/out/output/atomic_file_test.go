@@ -0,0 +1,191 @@
+package output_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("AtomicFile", func() {
+	var (
+		tempDir  string
+		filePath string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "atomic_file_test_*")
+		Expect(err).NotTo(HaveOccurred())
+
+		filePath = filepath.Join(tempDir, "statement.json")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	listDir := func() []string {
+		entries, err := os.ReadDir(tempDir)
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		return names
+	}
+
+	Context("when committing", func() {
+		It("should only expose the file after commit", func() {
+			file, err := output.CreateAtomic(filePath, output.FileOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = file.Write([]byte("partial"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filePath).NotTo(BeAnExistingFile())
+
+			Expect(file.Commit()).To(Succeed())
+			Expect(file.Close()).To(Succeed())
+
+			content, err := os.ReadFile(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("partial"))
+			Expect(listDir()).To(Equal([]string{"statement.json"}))
+		})
+
+		It("should apply the configured permissions", func() {
+			file, err := output.CreateAtomic(filePath, output.FileOptions{Perm: 0600})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Commit()).To(Succeed())
+
+			info, err := os.Stat(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		})
+
+		It("should default to 0644 permissions", func() {
+			file, err := output.CreateAtomic(filePath, output.FileOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Commit()).To(Succeed())
+
+			info, err := os.Stat(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(output.DefaultFilePerm))
+		})
+	})
+
+	Context("when aborting", func() {
+		It("should remove the temporary file and keep the existing file", func() {
+			Expect(os.WriteFile(filePath, []byte("previous"), 0644)).To(Succeed())
+
+			file, err := output.CreateAtomic(filePath, output.FileOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.Write([]byte("new"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+
+			content, err := os.ReadFile(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("previous"))
+			Expect(listDir()).To(Equal([]string{"statement.json"}))
+		})
+	})
+
+	Context("with no-clobber", func() {
+		It("should refuse to create over an existing file", func() {
+			Expect(os.WriteFile(filePath, []byte("previous"), 0644)).To(Succeed())
+
+			_, err := output.CreateAtomic(filePath, output.FileOptions{NoClobber: true})
+
+			Expect(errors.Is(err, fs.ErrExist)).To(BeTrue())
+		})
+
+		It("should refuse to commit when the file appeared after creation", func() {
+			file, err := output.CreateAtomic(filePath, output.FileOptions{NoClobber: true})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.WriteFile(filePath, []byte("concurrent"), 0644)).To(Succeed())
+
+			Expect(file.Commit()).NotTo(Succeed())
+			content, err := os.ReadFile(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("concurrent"))
+			Expect(listDir()).To(Equal([]string{"statement.json"}))
+		})
+
+		Context("on filesystems without hard links", func() {
+			BeforeEach(func() {
+				DeferCleanup(output.SetLink(func(oldname, newname string) error {
+					return &os.LinkError{Op: "link", Old: oldname, New: newname, Err: errors.ErrUnsupported}
+				}))
+			})
+
+			It("should copy the data into a new file", func() {
+				file, err := output.CreateAtomic(filePath, output.FileOptions{NoClobber: true, Perm: 0600})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = file.Write([]byte("statement"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(file.Commit()).To(Succeed())
+				content, err := os.ReadFile(filePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("statement"))
+				info, err := os.Stat(filePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+				Expect(listDir()).To(Equal([]string{"statement.json"}))
+			})
+
+			It("should still refuse to replace a file that appeared after creation", func() {
+				file, err := output.CreateAtomic(filePath, output.FileOptions{NoClobber: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(os.WriteFile(filePath, []byte("concurrent"), 0644)).To(Succeed())
+
+				Expect(errors.Is(file.Commit(), fs.ErrExist)).To(BeTrue())
+				content, err := os.ReadFile(filePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("concurrent"))
+				Expect(listDir()).To(Equal([]string{"statement.json"}))
+			})
+		})
+	})
+
+	Context("file-based writers", func() {
+		statement := domain.Statement{Period: "2025/01", Transactions: []domain.TransactionDTO{}}
+
+		It("should not clobber with JSONFileWriter when configured", func() {
+			Expect(os.WriteFile(filePath, []byte("previous"), 0644)).To(Succeed())
+
+			writer := output.NewJSONFile(filePath)
+			writer.Options = output.FileOptions{NoClobber: true}
+
+			Expect(writer.Write(context.Background(), statement)).NotTo(Succeed())
+			content, err := os.ReadFile(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("previous"))
+		})
+
+		It("should not leave a truncated file when rendering fails", func() {
+			Expect(os.WriteFile(filePath, []byte("previous"), 0644)).To(Succeed())
+
+			tmplPath := filepath.Join(tempDir, "broken.tmpl")
+			Expect(os.WriteFile(tmplPath, []byte(`{{ .Period }}{{ money "oops" }}`), 0644)).To(Succeed())
+			tmpl, err := output.ParseTemplateFile(tmplPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(output.NewTemplateFile(filePath, tmpl).Write(context.Background(), statement)).NotTo(Succeed())
+
+			content, err := os.ReadFile(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("previous"))
+		})
+	})
+})
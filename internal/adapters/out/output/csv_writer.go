@@ -0,0 +1,44 @@
+package output
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"mf-statement/internal/domain"
+)
+
+// CSVWriter writes the statement transactions in the date,amount,content input format
+type CSVWriter struct{ W io.Writer }
+
+func NewCSV(w io.Writer) *CSVWriter { return &CSVWriter{W: w} }
+
+func (c *CSVWriter) Write(ctx context.Context, s domain.Statement) error {
+	writer := csv.NewWriter(c.W)
+	if err := writer.Write([]string{"date", "amount", "content"}); err != nil {
+		return err
+	}
+
+	for _, tx := range s.Transactions {
+		if err := writer.Write([]string{tx.Date, tx.Amount, tx.Content}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+type CSVFileWriter struct {
+	FilePath string
+	Options  FileOptions
+}
+
+func NewCSVFile(filePath string) *CSVFileWriter {
+	return &CSVFileWriter{FilePath: filePath}
+}
+
+func (c *CSVFileWriter) Write(ctx context.Context, s domain.Statement) error {
+	return writeFileAtomic(c.FilePath, c.Options, func(w io.Writer) error {
+		return NewCSV(w).Write(ctx, s)
+	})
+}
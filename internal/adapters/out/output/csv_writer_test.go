@@ -0,0 +1,29 @@
+package output_test
+
+import (
+	"bytes"
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("CSVWriter", func() {
+	It("should write transactions in the input CSV format", func() {
+		var buf bytes.Buffer
+		statement := domain.Statement{
+			Period: "2025/01",
+			Transactions: []domain.TransactionDTO{
+				{Date: "2025/01/05", Amount: "-300", Content: "Grocery, weekly"},
+				{Date: "2025/01/01", Amount: "2000", Content: "Salary"},
+			},
+		}
+
+		Expect(output.NewCSV(&buf).Write(context.Background(), statement)).To(Succeed())
+
+		Expect(buf.String()).To(Equal("date,amount,content\n2025/01/05,-300,\"Grocery, weekly\"\n2025/01/01,2000,Salary\n"))
+	})
+})
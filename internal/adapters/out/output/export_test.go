@@ -0,0 +1,9 @@
+package output
+
+import "os"
+
+// SetLink replaces os.Link for the tests, returning a function restoring it
+func SetLink(fn func(oldname, newname string) error) (restore func()) {
+	link = fn
+	return func() { link = os.Link }
+}
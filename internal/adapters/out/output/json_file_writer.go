@@ -2,12 +2,13 @@ package output
 
 import (
 	"context"
+	"io"
 	"mf-statement/internal/domain"
-	"os"
 )
 
 type JSONFileWriter struct {
 	FilePath string
+	Options  FileOptions
 }
 
 func NewJSONFile(filePath string) *JSONFileWriter {
@@ -15,12 +16,7 @@ func NewJSONFile(filePath string) *JSONFileWriter {
 }
 
 func (j *JSONFileWriter) Write(ctx context.Context, s domain.Statement) error {
-	file, err := os.Create(j.FilePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	writer := NewJSON(file)
-	return writer.Write(ctx, s)
+	return writeFileAtomic(j.FilePath, j.Options, func(w io.Writer) error {
+		return NewJSON(w).Write(ctx, s)
+	})
 }
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"mf-statement/internal/domain"
+	"strings"
+)
+
+// Destination pairs a writer with the name used to report its failures
+type Destination struct {
+	Name   string
+	Writer Writer
+}
+
+// MultiWriter writes the same statement to several destinations. A failing
+// destination does not prevent the remaining ones from being written.
+type MultiWriter struct {
+	Destinations []Destination
+}
+
+func NewMulti(destinations ...Destination) *MultiWriter {
+	return &MultiWriter{Destinations: destinations}
+}
+
+func (m *MultiWriter) Write(ctx context.Context, s domain.Statement) error {
+	var result MultiWriteError
+	for _, destination := range m.Destinations {
+		if err := destination.Writer.Write(ctx, s); err != nil {
+			result.Failures = append(result.Failures, DestinationError{Name: destination.Name, Err: err})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, destination.Name)
+	}
+
+	if len(result.Failures) > 0 {
+		return &result
+	}
+	return nil
+}
+
+// DestinationError is the failure of a single destination of a MultiWriter
+type DestinationError struct {
+	Name string
+	Err  error
+}
+
+func (e DestinationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Name, e.Err)
+}
+
+func (e DestinationError) Unwrap() error {
+	return e.Err
+}
+
+// MultiWriteError reports which destinations failed and which were written
+type MultiWriteError struct {
+	Failures  []DestinationError
+	Succeeded []string
+}
+
+func (e *MultiWriteError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		messages[i] = failure.Error()
+	}
+
+	msg := fmt.Sprintf("%d of %d destinations failed: %s",
+		len(e.Failures), len(e.Failures)+len(e.Succeeded), strings.Join(messages, "; "))
+	if len(e.Succeeded) > 0 {
+		msg += fmt.Sprintf(" (written: %s)", strings.Join(e.Succeeded, ", "))
+	}
+	return msg
+}
+
+func (e *MultiWriteError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure
+	}
+	return errs
+}
@@ -0,0 +1,80 @@
+package output_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+)
+
+type failingStatementWriter struct{ err error }
+
+func (f failingStatementWriter) Write(ctx context.Context, s domain.Statement) error { return f.err }
+
+var _ = Describe("MultiWriter", func() {
+	var (
+		ctx       context.Context
+		tempDir   string
+		statement domain.Statement
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "multi_writer_test_*")
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx = context.Background()
+		statement = domain.Statement{
+			Period:       "2025/01",
+			TotalIncome:  1000,
+			Transactions: []domain.TransactionDTO{{Date: "2025/01/01", Amount: "1000", Content: "Salary"}},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("should write the statement to every destination", func() {
+		jsonPath := filepath.Join(tempDir, "statement.json")
+		csvPath := filepath.Join(tempDir, "statement.csv")
+
+		writer := output.NewMulti(
+			output.Destination{Name: jsonPath, Writer: output.NewJSONFile(jsonPath)},
+			output.Destination{Name: csvPath, Writer: output.NewCSVFile(csvPath)},
+		)
+
+		Expect(writer.Write(ctx, statement)).To(Succeed())
+		Expect(jsonPath).To(BeAnExistingFile())
+		Expect(csvPath).To(BeAnExistingFile())
+	})
+
+	It("should keep successful outputs and report failed destinations", func() {
+		jsonPath := filepath.Join(tempDir, "statement.json")
+		cause := errors.New("bucket unavailable")
+
+		writer := output.NewMulti(
+			output.Destination{Name: "remote", Writer: failingStatementWriter{err: cause}},
+			output.Destination{Name: jsonPath, Writer: output.NewJSONFile(jsonPath)},
+		)
+
+		err := writer.Write(ctx, statement)
+
+		Expect(err).To(HaveOccurred())
+		Expect(jsonPath).To(BeAnExistingFile())
+		Expect(errors.Is(err, cause)).To(BeTrue())
+
+		var multiErr *output.MultiWriteError
+		Expect(errors.As(err, &multiErr)).To(BeTrue())
+		Expect(multiErr.Failures).To(HaveLen(1))
+		Expect(multiErr.Failures[0].Name).To(Equal("remote"))
+		Expect(multiErr.Succeeded).To(Equal([]string{jsonPath}))
+		Expect(err.Error()).To(ContainSubstring("1 of 2 destinations failed"))
+	})
+})
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mf-statement/internal/domain"
+)
+
+const (
+	RecordTypeTransaction = "transaction"
+	RecordTypeDuplicate   = "duplicate"
+	RecordTypeSummary     = "summary"
+)
+
+// StreamWriter receives transactions one at a time as they are produced,
+// followed by a single summary once the input has been fully consumed
+type StreamWriter interface {
+	WriteTransaction(ctx context.Context, tx domain.Transaction) error
+	WriteSummary(ctx context.Context, summary domain.Summary) error
+}
+
+type transactionRecord struct {
+	Type string `json:"type"`
+	domain.TransactionDTO
+}
+
+type summaryRecord struct {
+	Type string `json:"type"`
+	domain.Summary
+}
+
+// NDJSONWriter writes newline-delimited JSON: one record per transaction and
+// per reported duplicate, followed by a summary record
+type NDJSONWriter struct {
+	W   io.Writer
+	enc *json.Encoder
+}
+
+func NewNDJSON(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{W: w, enc: json.NewEncoder(w)}
+}
+
+func (n *NDJSONWriter) Write(ctx context.Context, s domain.Statement) error {
+	for _, tx := range s.Transactions {
+		if err := n.writeTransactionDTO(tx); err != nil {
+			return err
+		}
+	}
+	for _, tx := range s.Duplicates {
+		if err := n.encoder().Encode(transactionRecord{Type: RecordTypeDuplicate, TransactionDTO: tx}); err != nil {
+			return err
+		}
+	}
+	return n.WriteSummary(ctx, s.Summary())
+}
+
+func (n *NDJSONWriter) WriteTransaction(ctx context.Context, tx domain.Transaction) error {
+	return n.writeTransactionDTO(domain.NewTransactionDTO(tx))
+}
+
+func (n *NDJSONWriter) WriteSummary(ctx context.Context, summary domain.Summary) error {
+	return n.encoder().Encode(summaryRecord{Type: RecordTypeSummary, Summary: summary})
+}
+
+func (n *NDJSONWriter) writeTransactionDTO(tx domain.TransactionDTO) error {
+	return n.encoder().Encode(transactionRecord{Type: RecordTypeTransaction, TransactionDTO: tx})
+}
+
+func (n *NDJSONWriter) encoder() *json.Encoder {
+	if n.enc == nil {
+		n.enc = json.NewEncoder(n.W)
+	}
+	return n.enc
+}
+
+// NDJSONFileWriter writes a statement as newline-delimited JSON into a file
+type NDJSONFileWriter struct {
+	FilePath string
+	Options  FileOptions
+}
+
+func NewNDJSONFile(filePath string) *NDJSONFileWriter {
+	return &NDJSONFileWriter{FilePath: filePath}
+}
+
+func (n *NDJSONFileWriter) Write(ctx context.Context, s domain.Statement) error {
+	return writeFileAtomic(n.FilePath, n.Options, func(w io.Writer) error {
+		return NewNDJSON(w).Write(ctx, s)
+	})
+}
@@ -0,0 +1,126 @@
+package output_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("NDJSONWriter", func() {
+	var (
+		buf    *bytes.Buffer
+		writer *output.NDJSONWriter
+		ctx    context.Context
+	)
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		writer = output.NewNDJSON(buf)
+		ctx = context.Background()
+	})
+
+	decodeLines := func(data string) []map[string]interface{} {
+		var records []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+			var record map[string]interface{}
+			Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+			records = append(records, record)
+		}
+		return records
+	}
+
+	Context("when writing a statement", func() {
+		It("should write one record per transaction followed by a summary", func() {
+			statement := domain.Statement{
+				Period:           "2025/01",
+				TotalIncome:      2000,
+				TotalExpenditure: -500,
+				Transactions: []domain.TransactionDTO{
+					{Date: "2025/01/05", Amount: "-500", Content: "Groceries"},
+					{Date: "2025/01/01", Amount: "2000", Content: "Salary"},
+				},
+			}
+
+			Expect(writer.Write(ctx, statement)).To(Succeed())
+
+			records := decodeLines(buf.String())
+			Expect(records).To(HaveLen(3))
+			Expect(records[0]["type"]).To(Equal("transaction"))
+			Expect(records[0]["content"]).To(Equal("Groceries"))
+			Expect(records[1]["amount"]).To(Equal("2000"))
+			Expect(records[2]["type"]).To(Equal("summary"))
+			Expect(records[2]["period"]).To(Equal("2025/01"))
+			Expect(records[2]["total_income"]).To(BeNumerically("==", 2000))
+			Expect(records[2]["transaction_count"]).To(BeNumerically("==", 2))
+		})
+
+		It("should write only a summary for an empty statement", func() {
+			Expect(writer.Write(ctx, domain.Statement{Period: "2025/01"})).To(Succeed())
+
+			records := decodeLines(buf.String())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0]["type"]).To(Equal("summary"))
+		})
+
+		It("should write reported duplicates before the summary", func() {
+			statement := domain.Statement{
+				Period:       "2025/01",
+				Transactions: []domain.TransactionDTO{{Date: "2025/01/01", Amount: "2000", Content: "Salary", Source: "old.csv"}},
+				Duplicates:   []domain.TransactionDTO{{Date: "2025/01/01", Amount: "2000", Content: "Salary", Source: "new.csv"}},
+			}
+
+			Expect(writer.Write(ctx, statement)).To(Succeed())
+
+			records := decodeLines(buf.String())
+			Expect(records).To(HaveLen(3))
+			Expect(records[1]["type"]).To(Equal("duplicate"))
+			Expect(records[1]["source"]).To(Equal("new.csv"))
+			Expect(records[2]["transaction_count"]).To(BeNumerically("==", 1))
+		})
+	})
+
+	Context("when streaming", func() {
+		It("should write transactions as they arrive", func() {
+			tx := domain.Transaction{Date: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), Amount: -300, Content: "Grocery"}
+
+			Expect(writer.WriteTransaction(ctx, tx)).To(Succeed())
+			Expect(buf.String()).To(Equal(`{"type":"transaction","date":"2025/01/02","amount":"-300","content":"Grocery"}` + "\n"))
+
+			Expect(writer.WriteSummary(ctx, domain.Summary{Period: "2025/01", TotalExpenditure: -300, TransactionCount: 1})).To(Succeed())
+			records := decodeLines(buf.String())
+			Expect(records).To(HaveLen(2))
+			Expect(records[1]["type"]).To(Equal("summary"))
+		})
+	})
+
+	Context("NDJSONFileWriter", func() {
+		It("should write newline-delimited JSON to a file", func() {
+			tempDir, err := os.MkdirTemp("", "ndjson_writer_test_*")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+
+			filePath := filepath.Join(tempDir, "statement.ndjson")
+			statement := domain.Statement{
+				Period:       "2025/01",
+				TotalIncome:  1000,
+				Transactions: []domain.TransactionDTO{{Date: "2025/01/01", Amount: "1000", Content: "Salary"}},
+			}
+
+			Expect(output.NewNDJSONFile(filePath).Write(ctx, statement)).To(Succeed())
+
+			content, err := os.ReadFile(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decodeLines(string(content))).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/minio/minio-go/v7"
+
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/domain"
+)
+
+// S3Writer renders a statement with the writer returned by Encode and uploads it
+// as a single object, so readers never observe a partially written statement
+type S3Writer struct {
+	Client      *minio.Client
+	URI         string
+	ContentType string
+	// NoClobber makes the upload conditional on the object not existing yet
+	NoClobber bool
+	Encode    func(w io.Writer) Writer
+}
+
+func NewS3(client *minio.Client, uri string, encode func(w io.Writer) Writer) *S3Writer {
+	return &S3Writer{Client: client, URI: uri, Encode: encode}
+}
+
+func (s *S3Writer) Write(ctx context.Context, st domain.Statement) error {
+	bucket, key, err := objectstore.ParseURI(s.URI)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := s.Encode(&buf).Write(ctx, st); err != nil {
+		return err
+	}
+
+	opts := minio.PutObjectOptions{ContentType: s.ContentType}
+	if s.NoClobber {
+		opts.SetMatchETagExcept("*")
+	}
+	if _, err := s.Client.PutObject(ctx, bucket, key, &buf, int64(buf.Len()), opts); err != nil {
+		if minio.ToErrorResponse(err).Code == "PreconditionFailed" {
+			return fmt.Errorf("%s: %w", s.URI, fs.ErrExist)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package output_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/adapters/objectstore/objectstoretest"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("S3Writer", func() {
+	var (
+		server    *objectstoretest.Server
+		writer    *output.S3Writer
+		statement domain.Statement
+	)
+
+	BeforeEach(func() {
+		server = objectstoretest.NewServer()
+		DeferCleanup(server.Close)
+
+		client, err := objectstore.NewClient(server.Config())
+		Expect(err).NotTo(HaveOccurred())
+		writer = output.NewS3(client, "s3://statements/2025-01.csv", func(w io.Writer) output.Writer { return output.NewCSV(w) })
+		writer.ContentType = "text/csv"
+
+		statement = domain.Statement{
+			Period:       "2025/01",
+			Transactions: []domain.TransactionDTO{{Date: "2025/01/01", Amount: "2000", Content: "Salary"}},
+		}
+	})
+
+	It("should upload the encoded statement", func() {
+		Expect(writer.Write(context.Background(), statement)).To(Succeed())
+
+		content, ok := server.Get("statements", "2025-01.csv")
+		Expect(ok).To(BeTrue())
+		Expect(string(content)).To(Equal("date,amount,content\n2025/01/01,2000,Salary\n"))
+		Expect(server.Header("statements", "2025-01.csv").Get("Content-Type")).To(Equal("text/csv"))
+	})
+
+	It("should refuse to replace an existing object with NoClobber", func() {
+		server.Put("statements", "2025-01.csv", []byte("previous"))
+		writer.NoClobber = true
+
+		Expect(writer.Write(context.Background(), statement)).To(MatchError(fs.ErrExist))
+
+		content, _ := server.Get("statements", "2025-01.csv")
+		Expect(string(content)).To(Equal("previous"))
+	})
+})
@@ -0,0 +1,17 @@
+package output
+
+import (
+	"context"
+
+	"mf-statement/internal/domain"
+)
+
+// StatementCapture keeps the generated statement so the servers can return it in their response
+type StatementCapture struct {
+	Statement domain.Statement
+}
+
+func (c *StatementCapture) Write(ctx context.Context, s domain.Statement) error {
+	c.Statement = s
+	return nil
+}
@@ -0,0 +1,171 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mf-statement/internal/domain"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateWriter renders a statement through a user-supplied text/template
+type TemplateWriter struct {
+	W        io.Writer
+	Template *template.Template
+}
+
+func NewTemplate(w io.Writer, tmpl *template.Template) *TemplateWriter {
+	return &TemplateWriter{W: w, Template: tmpl}
+}
+
+func (t *TemplateWriter) Write(ctx context.Context, s domain.Statement) error {
+	return t.Template.Execute(t.W, s)
+}
+
+// TemplateFileWriter renders a statement through a template into a file
+type TemplateFileWriter struct {
+	FilePath string
+	Template *template.Template
+	Options  FileOptions
+}
+
+func NewTemplateFile(filePath string, tmpl *template.Template) *TemplateFileWriter {
+	return &TemplateFileWriter{FilePath: filePath, Template: tmpl}
+}
+
+func (t *TemplateFileWriter) Write(ctx context.Context, s domain.Statement) error {
+	return writeFileAtomic(t.FilePath, t.Options, func(w io.Writer) error {
+		return NewTemplate(w, t.Template).Write(ctx, s)
+	})
+}
+
+// ParseTemplateFile loads a template from disk with the helper functions available
+func ParseTemplateFile(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return template.New(filepath.Base(path)).Funcs(TemplateFuncs()).Parse(string(content))
+}
+
+// TemplateFuncs returns the helper functions exposed to statement templates:
+//
+//	money         formats an amount with thousands separators (1234567 -> 1,234,567)
+//	moneyDecimal  formats an amount in minor units with the given decimals (moneyDecimal 1234 2 -> 12.34)
+//	date          reformats a statement date with a Go time layout
+//	abs           returns the absolute value of an amount
+//	sumByCategory totals transaction amounts per category; transactions without one,
+//	              such as every CSV row, are totalled under Uncategorized
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"money":         formatMoney,
+		"moneyDecimal":  formatMoneyDecimal,
+		"date":          formatDate,
+		"abs":           absAmount,
+		"sumByCategory": sumByCategory,
+	}
+}
+
+func toAmount(v interface{}) (int64, error) {
+	switch a := v.(type) {
+	case int64:
+		return a, nil
+	case int:
+		return int64(a), nil
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(a), 10, 64)
+	default:
+		return 0, fmt.Errorf("unsupported amount type %T", v)
+	}
+}
+
+func formatMoney(v interface{}) (string, error) {
+	amount, err := toAmount(v)
+	if err != nil {
+		return "", err
+	}
+	return groupThousands(amount), nil
+}
+
+func formatMoneyDecimal(v interface{}, decimals int) (string, error) {
+	amount, err := toAmount(v)
+	if err != nil {
+		return "", err
+	}
+	if decimals <= 0 {
+		return groupThousands(amount), nil
+	}
+
+	// Splitting the digits rather than dividing by 10^decimals cannot overflow
+	digits := strconv.FormatInt(amount, 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	split := len(digits) - decimals
+	return sign + groupDigits(digits[:split]) + "." + digits[split:], nil
+}
+
+func groupThousands(amount int64) string {
+	digits := strconv.FormatInt(amount, 10)
+	if strings.HasPrefix(digits, "-") {
+		return "-" + groupDigits(digits[1:])
+	}
+	return groupDigits(digits)
+}
+
+// groupDigits inserts thousands separators into a string of digits
+func groupDigits(digits string) string {
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+func formatDate(layout string, value string) (string, error) {
+	t, err := time.Parse(domain.CSVDateLayout, value)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+func absAmount(v interface{}) (int64, error) {
+	amount, err := toAmount(v)
+	if err != nil {
+		return 0, err
+	}
+	if amount < 0 {
+		return -amount, nil
+	}
+	return amount, nil
+}
+
+func sumByCategory(transactions []domain.TransactionDTO) (map[string]int64, error) {
+	sums := make(map[string]int64)
+	for _, tx := range transactions {
+		amount, err := toAmount(tx.Amount)
+		if err != nil {
+			return nil, err
+		}
+		category := tx.Category
+		if category == "" {
+			category = domain.UncategorizedLabel
+		}
+		sums[category] += amount
+	}
+	return sums, nil
+}
@@ -0,0 +1,120 @@
+package output_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("TemplateWriter", func() {
+	var (
+		ctx       context.Context
+		tempDir   string
+		statement domain.Statement
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "template_writer_test_*")
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx = context.Background()
+		statement = domain.Statement{
+			Period:           "2025/01",
+			TotalIncome:      1234567,
+			TotalExpenditure: -1500,
+			Transactions: []domain.TransactionDTO{
+				{Date: "2025/01/09", Amount: "-1000", Content: "Grocery", Category: "Food"},
+				{Date: "2025/01/07", Amount: "-500", Content: "Lunch", Category: "Food"},
+				{Date: "2025/01/05", Amount: "1234567", Content: "Salary"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	render := func(text string) (string, error) {
+		tmpl, err := template.New("test").Funcs(output.TemplateFuncs()).Parse(text)
+		Expect(err).NotTo(HaveOccurred())
+
+		var buf bytes.Buffer
+		err = output.NewTemplate(&buf, tmpl).Write(ctx, statement)
+		return buf.String(), err
+	}
+
+	Context("helper functions", func() {
+		It("should format money with thousands separators", func() {
+			out, err := render(`{{ money .TotalIncome }}|{{ money .TotalExpenditure }}`)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("1,234,567|-1,500"))
+		})
+
+		It("should format money with decimals", func() {
+			out, err := render(`{{ moneyDecimal .TotalIncome 2 }}|{{ moneyDecimal "-5" 2 }}`)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("12,345.67|-0.05"))
+		})
+
+		It("should format extreme amounts and decimals without overflowing", func() {
+			out, err := render(`{{ moneyDecimal "-9223372036854775808" 2 }}|{{ moneyDecimal "42" 21 }}`)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("-92,233,720,368,547,758.08|0.000000000000000000042"))
+		})
+
+		It("should reformat transaction dates", func() {
+			out, err := render(`{{ range .Transactions }}{{ date "2006-01-02" .Date }};{{ end }}`)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("2025-01-09;2025-01-07;2025-01-05;"))
+		})
+
+		It("should sum amounts by category", func() {
+			out, err := render(`{{ range $c, $sum := sumByCategory .Transactions }}{{ $c }}={{ abs $sum }};{{ end }}`)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("Food=1500;Uncategorized=1234567;"))
+		})
+
+		It("should return error for invalid amounts", func() {
+			_, err := render(`{{ money "abc" }}`)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("TemplateFileWriter", func() {
+		It("should render the template into a file", func() {
+			tmplPath := filepath.Join(tempDir, "statement.tmpl")
+			Expect(os.WriteFile(tmplPath, []byte(`Statement {{ .Period }}: {{ len .Transactions }} transactions`), 0644)).To(Succeed())
+
+			tmpl, err := output.ParseTemplateFile(tmplPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			outPath := filepath.Join(tempDir, "statement.txt")
+			Expect(output.NewTemplateFile(outPath, tmpl).Write(ctx, statement)).To(Succeed())
+
+			content, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("Statement 2025/01: 3 transactions"))
+		})
+
+		It("should return error for missing template file", func() {
+			_, err := output.ParseTemplateFile(filepath.Join(tempDir, "missing.tmpl"))
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
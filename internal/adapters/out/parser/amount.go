@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultMinorUnits is the number of decimals of most currencies
+const DefaultMinorUnits = 2
+
+// minorUnits lists ISO 4217 currencies without two decimals
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// MinorUnits returns the decimals of a currency code, DefaultMinorUnits when unknown or empty
+func MinorUnits(currency string) int {
+	if units, ok := minorUnits[strings.ToUpper(strings.TrimSpace(currency))]; ok {
+		return units
+	}
+	return DefaultMinorUnits
+}
+
+// ParseDecimalAmount converts a decimal amount such as "-1234.50" into minor units
+// without going through floating point. A comma is accepted as decimal separator
+// when it is the only separator. Fractions finer than the minor unit are rejected
+// unless they are zeros.
+func ParseDecimalAmount(amount string, units int) (int64, error) {
+	s := strings.TrimSpace(amount)
+	if strings.Contains(s, ",") && !strings.Contains(s, ".") {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if len(fraction) > units {
+		if strings.Trim(fraction[units:], "0") != "" {
+			return 0, fmt.Errorf("amount %q has more than %d decimals", amount, units)
+		}
+		fraction = fraction[:units]
+	}
+	fraction += strings.Repeat("0", units-len(fraction))
+
+	for _, part := range []string{whole, fraction} {
+		if strings.Trim(part, "0123456789") != "" {
+			return 0, fmt.Errorf("invalid amount %q", amount)
+		}
+	}
+
+	value, err := strconv.ParseInt(sign+whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,46 @@
+package parser_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+)
+
+var _ = Describe("ParseDecimalAmount", func() {
+	DescribeTable("should convert decimals to minor units exactly",
+		func(amount string, units int, expected int64) {
+			Expect(parser.ParseDecimalAmount(amount, units)).To(Equal(expected))
+		},
+		Entry("cents", "-12.50", 2, int64(-1250)),
+		Entry("whole amount", "2000", 2, int64(200000)),
+		Entry("single decimal", "3.5", 2, int64(350)),
+		Entry("explicit plus sign", "+0.07", 2, int64(7)),
+		Entry("leading decimal point", ".99", 2, int64(99)),
+		Entry("comma separator", "1234,56", 2, int64(123456)),
+		Entry("trailing zeros beyond the minor unit", "10.5000", 2, int64(1050)),
+		Entry("zero-decimal currency", "-1500", 0, int64(-1500)),
+		Entry("three-decimal currency", "1.234", 3, int64(1234)),
+		Entry("value that is not exact in floating point", "0.29", 2, int64(29)),
+	)
+
+	DescribeTable("should reject invalid amounts",
+		func(amount string, units int) {
+			_, err := parser.ParseDecimalAmount(amount, units)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", "", 2),
+		Entry("letters", "12.5a", 2),
+		Entry("thousands separators", "1,234.56", 2),
+		Entry("sub-unit precision", "1.005", 2),
+		Entry("decimals in a zero-decimal currency", "100.5", 0),
+		Entry("overflow", "99999999999999999999", 2),
+	)
+
+	It("should look up currency minor units", func() {
+		Expect(parser.MinorUnits("JPY")).To(Equal(0))
+		Expect(parser.MinorUnits("kwd")).To(Equal(3))
+		Expect(parser.MinorUnits("EUR")).To(Equal(2))
+		Expect(parser.MinorUnits("")).To(Equal(parser.DefaultMinorUnits))
+	})
+})
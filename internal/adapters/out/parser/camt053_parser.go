@@ -0,0 +1,284 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"mf-statement/internal/domain"
+)
+
+// Camt053Parser reads ISO 20022 camt.053 bank-to-customer statements. Elements are
+// matched by local name, so every message version (camt.053.001.02 to .12) is read.
+// Only booked entries are returned, which are the ones the balances account for.
+type Camt053Parser struct {
+	// Reconcile fails parsing when a statement's balances do not match its entries
+	Reconcile bool
+}
+
+func NewCamt053() *Camt053Parser { return &Camt053Parser{} }
+
+// LooksLikeCamt053 reports whether the start of an input is a camt.053 document
+func LooksLikeCamt053(header []byte) bool {
+	return bytes.Contains(header, []byte("camt.053")) || bytes.Contains(header, []byte("<BkToCstmrStmt"))
+}
+
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	ID       string        `xml:"Id"`
+	IBAN     string        `xml:"Acct>Id>IBAN"`
+	Other    string        `xml:"Acct>Id>Othr>Id"`
+	Currency string        `xml:"Acct>Ccy"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+type camtEntry struct {
+	Reference      string         `xml:"NtryRef"`
+	Amount         camtAmount     `xml:"Amt"`
+	Indicator      string         `xml:"CdtDbtInd"`
+	Status         camtStatus     `xml:"Sts"`
+	BookingDate    camtDate       `xml:"BookgDt"`
+	ValueDate      camtDate       `xml:"ValDt"`
+	ServicerRef    string         `xml:"AcctSvcrRef"`
+	Details        []camtTxDetail `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo string         `xml:"AddtlNtryInf"`
+}
+
+// camtStatus is a plain code up to camt.053.001.06 and a Cd element afterwards
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtTxDetail struct {
+	Unstructured []string `xml:"RmtInf>Ustrd"`
+	Structured   []string `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	Creditor     string   `xml:"RltdPties>Cdtr>Nm"`
+	CreditorPty  string   `xml:"RltdPties>Cdtr>Pty>Nm"`
+	Debtor       string   `xml:"RltdPties>Dbtr>Nm"`
+	DebtorPty    string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+}
+
+func (p *Camt053Parser) Parse(ctx context.Context, r io.Reader) ([]domain.Transaction, error) {
+	statements, err := p.ParseStatements(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return flattenStatements(statements, p.Reconcile)
+}
+
+// ParseStatements returns every statement of the document with its reported balances
+func (p *Camt053Parser) ParseStatements(ctx context.Context, r io.Reader) ([]domain.BankStatement, error) {
+	var document camtDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, domain.NewParseError("failed to decode camt.053 XML", err)
+	}
+	if len(document.Statements) == 0 {
+		return nil, domain.NewParseError("not a camt.053 document: no BkToCstmrStmt/Stmt element", nil)
+	}
+
+	out := make([]domain.BankStatement, 0, len(document.Statements))
+	for _, stmt := range document.Statements {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		statement, err := stmt.toBankStatement()
+		if err != nil {
+			return nil, fmt.Errorf("statement %s: %w", stmt.ID, err)
+		}
+		out = append(out, statement)
+	}
+	return out, nil
+}
+
+func (s camtStatement) toBankStatement() (domain.BankStatement, error) {
+	statement := domain.BankStatement{ID: s.ID, Account: s.IBAN, Currency: s.Currency}
+	if statement.Account == "" {
+		statement.Account = s.Other
+	}
+
+	for _, bal := range s.Balances {
+		balance, err := bal.toBalance(s.Currency)
+		if err != nil {
+			return domain.BankStatement{}, err
+		}
+		switch strings.ToUpper(bal.Code) {
+		case "OPBD", "PRCD":
+			if statement.Opening == nil || bal.Code == "OPBD" {
+				statement.Opening = &balance
+			}
+		case "CLBD":
+			statement.Closing = &balance
+		}
+	}
+
+	for i, entry := range s.Entries {
+		if !entry.isBooked() {
+			continue
+		}
+		tx, err := entry.toTransaction(s.Currency)
+		if err != nil {
+			return domain.BankStatement{}, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		statement.Transactions = append(statement.Transactions, tx)
+	}
+	return statement, nil
+}
+
+func (b camtBalance) toBalance(accountCurrency string) (domain.Balance, error) {
+	amount, err := b.Amount.signed(b.Indicator, accountCurrency)
+	if err != nil {
+		return domain.Balance{}, domain.NewParseError(fmt.Sprintf("failed to parse %s balance", b.Code), err)
+	}
+	date, err := b.Date.parse()
+	if err != nil {
+		return domain.Balance{}, domain.NewParseError(fmt.Sprintf("failed to parse %s balance date", b.Code), err)
+	}
+	return domain.Balance{Date: date, Amount: amount}, nil
+}
+
+func (e camtEntry) isBooked() bool {
+	code := strings.TrimSpace(e.Status.Code)
+	if code == "" {
+		code = strings.TrimSpace(e.Status.Text)
+	}
+	// Entries without a status are treated as booked
+	return code == "" || strings.EqualFold(code, "BOOK")
+}
+
+func (e camtEntry) toTransaction(accountCurrency string) (domain.Transaction, error) {
+	amount, err := e.Amount.signed(e.Indicator, accountCurrency)
+	if err != nil {
+		return domain.Transaction{}, domain.NewParseError("failed to parse entry amount", err)
+	}
+
+	bookingDate := e.BookingDate
+	if bookingDate.Date == "" && bookingDate.DateTime == "" {
+		bookingDate = e.ValueDate
+	}
+	date, err := bookingDate.parse()
+	if err != nil {
+		return domain.Transaction{}, domain.NewParseError("failed to parse booking date", err)
+	}
+
+	tx, err := domain.NewTransaction(date, amount, e.content(amount < 0))
+	if err != nil {
+		return domain.Transaction{}, domain.NewValidationError("entry has no remittance information", map[string]interface{}{
+			"reference": e.ServicerRef,
+		})
+	}
+	tx.ID = e.ServicerRef
+	if tx.ID == "" {
+		tx.ID = e.Reference
+	}
+	return tx, nil
+}
+
+// content describes an entry by its counterparty and remittance information,
+// falling back to the bank's additional entry information
+func (e camtEntry) content(debit bool) string {
+	var (
+		counterparty string
+		remittance   []string
+	)
+	for _, detail := range e.Details {
+		if counterparty == "" {
+			if debit {
+				counterparty = firstNonEmpty(detail.Creditor, detail.CreditorPty)
+			} else {
+				counterparty = firstNonEmpty(detail.Debtor, detail.DebtorPty)
+			}
+		}
+		remittance = append(remittance, detail.Unstructured...)
+		remittance = append(remittance, detail.Structured...)
+	}
+
+	info := strings.Join(strings.Fields(strings.Join(remittance, " ")), " ")
+	if info == "" {
+		info = strings.TrimSpace(e.AdditionalInfo)
+	}
+	return joinContent(strings.TrimSpace(counterparty), info)
+}
+
+func (a camtAmount) signed(indicator, accountCurrency string) (int64, error) {
+	currency := a.Currency
+	if currency == "" {
+		currency = accountCurrency
+	}
+	amount, err := ParseDecimalAmount(a.Value, MinorUnits(currency))
+	if err != nil {
+		return 0, err
+	}
+	switch strings.ToUpper(strings.TrimSpace(indicator)) {
+	case "DBIT":
+		return -amount, nil
+	case "CRDT":
+		return amount, nil
+	default:
+		return 0, fmt.Errorf("invalid credit/debit indicator %q", indicator)
+	}
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	value := strings.TrimSpace(d.Date)
+	if value == "" {
+		value = strings.TrimSpace(d.DateTime)
+	}
+	// The calendar day of a date-time is the booking day in the bank's time zone
+	if len(value) > len(time.DateOnly) {
+		value = value[:len(time.DateOnly)]
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// flattenStatements concatenates the transactions of bank statements, checking
+// each statement's balances first when reconcile is set
+func flattenStatements(statements []domain.BankStatement, reconcile bool) ([]domain.Transaction, error) {
+	var out []domain.Transaction
+	for _, statement := range statements {
+		if reconcile {
+			if err := statement.Reconcile(); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, statement.Transactions...)
+	}
+	return out, nil
+}
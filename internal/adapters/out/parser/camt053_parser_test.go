@@ -0,0 +1,120 @@
+package parser_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+)
+
+const camt053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG-1</MsgId><CreDtTm>2025-02-01T06:00:00</CreDtTm></GrpHdr>
+    <Stmt>
+      <Id>STMT-2025-01</Id>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">1000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2025-01-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">2750.50</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2025-01-31</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <Amt Ccy="EUR">2000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2025-01-05</Dt></BookgDt>
+        <AcctSvcrRef>REF-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties><Dbtr><Pty><Nm>ACME GmbH</Nm></Pty></Dbtr></RltdPties>
+          <RmtInf><Ustrd>Salary</Ustrd><Ustrd>January</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">249.50</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2025-01-09T14:30:00+01:00</DtTm></BookgDt>
+        <NtryRef>N-2</NtryRef>
+        <NtryDtls><TxDtls>
+          <RltdPties><Cdtr><Nm>Grocer &amp; Co</Nm></Cdtr></RltdPties>
+        </TxDtls></NtryDtls>
+        <AddtlNtryInf>CARD PAYMENT</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">99.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2025-01-31</Dt></BookgDt>
+        <AddtlNtryInf>Pending</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+var _ = Describe("Camt053Parser", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should map booked entries to transactions", func() {
+		transactions, err := parser.NewCamt053().Parse(ctx, strings.NewReader(camt053))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(Equal([]domain.Transaction{
+			{Date: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), Amount: 200000, Content: "ACME GmbH - Salary January", ID: "REF-1"},
+			{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -24950, Content: "Grocer & Co - CARD PAYMENT", ID: "N-2"},
+		}))
+	})
+
+	It("should carry the reported balances", func() {
+		statements, err := parser.NewCamt053().ParseStatements(ctx, strings.NewReader(camt053))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statements).To(HaveLen(1))
+		Expect(statements[0].ID).To(Equal("STMT-2025-01"))
+		Expect(statements[0].Account).To(Equal("DE89370400440532013000"))
+		Expect(statements[0].Opening).To(Equal(&domain.Balance{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 100000}))
+		Expect(statements[0].Closing.Amount).To(Equal(int64(275050)))
+		Expect(statements[0].Reconcile()).To(Succeed())
+	})
+
+	It("should fail reconciliation when entries are missing", func() {
+		content := strings.Replace(camt053, "<Amt Ccy=\"EUR\">2750.50</Amt>", "<Amt Ccy=\"EUR\">2751.50</Amt>", 1)
+
+		transactions, err := parser.NewCamt053().Parse(ctx, strings.NewReader(content))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(2))
+
+		_, err = (&parser.Camt053Parser{Reconcile: true}).Parse(ctx, strings.NewReader(content))
+		Expect(err).To(MatchError(ContainSubstring("does not reconcile")))
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+
+	It("should report the failing entry", func() {
+		content := strings.Replace(camt053, "<CdtDbtInd>DBIT</CdtDbtInd>", "<CdtDbtInd>X</CdtDbtInd>", 1)
+
+		_, err := parser.NewCamt053().Parse(ctx, strings.NewReader(content))
+
+		Expect(err).To(MatchError(ContainSubstring("statement STMT-2025-01: entry 2")))
+	})
+
+	It("should reject other XML documents", func() {
+		_, err := parser.NewCamt053().Parse(ctx, strings.NewReader(ofxXML))
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should be recognised from its content", func() {
+		Expect(parser.LooksLikeCamt053([]byte(camt053))).To(BeTrue())
+		Expect(parser.LooksLikeCamt053([]byte("date,amount,content\n"))).To(BeFalse())
+	})
+})
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"mf-statement/internal/domain"
+)
+
+// CSVDialect describes how a delimited file lays out its header and records.
+// The zero value reads plain comma-separated files.
+type CSVDialect struct {
+	// Comma is the field delimiter; zero means ','
+	Comma rune
+	// Comment starts lines that are ignored; zero disables comments
+	Comment rune
+	// SkipLines is the number of preamble lines before the header
+	SkipLines int
+	// LazyQuotes accepts stray quotes in unquoted and quoted fields
+	LazyQuotes bool
+	// SkipTrailing is the number of summary rows after the records, such as totals
+	SkipTrailing int
+}
+
+// Validate rejects dialects encoding/csv cannot read
+func (d CSVDialect) Validate() error {
+	comma := d.Comma
+	if comma == 0 {
+		comma = ','
+	}
+	switch {
+	case comma == '"' || comma == '\r' || comma == '\n' || comma == utf8.RuneError:
+		return domain.NewValidationError("invalid CSV delimiter", map[string]interface{}{"delimiter": string(d.Comma)})
+	case d.Comment == comma || d.Comment == '"' || d.Comment == '\r' || d.Comment == '\n':
+		return domain.NewValidationError("invalid CSV comment character", map[string]interface{}{"comment": string(d.Comment)})
+	case d.SkipLines < 0 || d.SkipTrailing < 0:
+		return domain.NewValidationError("CSV lines to skip must not be negative", map[string]interface{}{
+			"skip_lines":    d.SkipLines,
+			"skip_trailing": d.SkipTrailing,
+		})
+	}
+	return nil
+}
+
+// readRecords validates the header with checkHeader and hands each record with its
+// line number to fn, holding back the trailing rows of the dialect. Records may be
+// reused between calls unless trailing rows are skipped.
+func (d CSVDialect) readRecords(ctx context.Context, r io.Reader, reuse bool, checkHeader func([]string) error, fn func(record []string, line int) error) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	buffered := bufio.NewReader(r)
+	if bom, _ := buffered.Peek(3); bytes.Equal(bom, []byte("\uFEFF")) {
+		_, _ = buffered.Discard(3)
+	}
+	for i := 0; i < d.SkipLines; i++ {
+		if _, err := buffered.ReadString('\n'); err != nil {
+			return fmt.Errorf("read header: %w", err)
+		}
+	}
+
+	reader := csv.NewReader(buffered)
+	if d.Comma != 0 {
+		reader.Comma = d.Comma
+	}
+	reader.Comment = d.Comment
+	reader.LazyQuotes = d.LazyQuotes
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = reuse && d.SkipTrailing == 0
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("read header: %w", err)
+	}
+	if err := checkHeader(header); err != nil {
+		return err
+	}
+
+	type pending struct {
+		record []string
+		line   int
+	}
+	var held []pending
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return fmt.Errorf("read record at line %d: %w", parseErr.StartLine+d.SkipLines, parseErr.Err)
+			}
+			return fmt.Errorf("read record: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		line += d.SkipLines
+
+		if d.SkipTrailing == 0 {
+			if err := fn(record, line); err != nil {
+				return err
+			}
+			continue
+		}
+		held = append(held, pending{record, line})
+		if len(held) > d.SkipTrailing {
+			if err := fn(held[0].record, held[0].line); err != nil {
+				return err
+			}
+			held = held[1:]
+		}
+	}
+}
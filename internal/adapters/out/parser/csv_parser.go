@@ -1,8 +1,8 @@
 package parser
 
 import (
+	"bytes"
 	"context"
-	"encoding/csv"
 	"fmt"
 	"io"
 	"strconv"
@@ -12,10 +12,21 @@ import (
 	"mf-statement/internal/domain"
 )
 
-type CSVParser struct{}
+type CSVParser struct {
+	Dialect CSVDialect
+}
 
 func NewCSV() *CSVParser { return &CSVParser{} }
 
+// NewTSV reads tab-separated values with the same columns as the CSV
+func NewTSV() *CSVParser { return &CSVParser{Dialect: CSVDialect{Comma: '\t'}} }
+
+// LooksLikeTSV reports whether the first line of an input is tab-separated rather than comma-separated
+func LooksLikeTSV(header []byte) bool {
+	line, _, _ := bytes.Cut(header, []byte("\n"))
+	return bytes.ContainsRune(line, '\t') && !bytes.ContainsRune(line, ',')
+}
+
 const (
 	colDate    = "date"
 	colAmount  = "amount"
@@ -23,44 +34,26 @@ const (
 )
 
 func (p *CSVParser) Parse(ctx context.Context, r io.Reader) ([]domain.Transaction, error) {
-	reader := csv.NewReader(r)
-	reader.FieldsPerRecord = -1
-	reader.TrimLeadingSpace = true
-
-	header, err := reader.Read()
+	var out []domain.Transaction
+	err := p.Stream(ctx, r, func(tx domain.Transaction) error {
+		out = append(out, tx)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("read header: %w", err)
-	}
-	if err := validateHeader(header); err != nil {
 		return nil, err
 	}
+	return out, nil
+}
 
-	var (
-		out      []domain.Transaction
-		rowIndex = 2
-	)
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("read record at line %d: %w", rowIndex, err)
-		}
+// Stream hands each transaction to emit as its record is read
+func (p *CSVParser) Stream(ctx context.Context, r io.Reader, emit func(domain.Transaction) error) error {
+	return p.Dialect.readRecords(ctx, r, false, validateHeader, func(record []string, line int) error {
 		tx, err := parseRecord(record)
 		if err != nil {
-			return nil, fmt.Errorf("line %d: %w", rowIndex, err)
+			return fmt.Errorf("line %d: %w", line, err)
 		}
-		out = append(out, tx)
-		rowIndex++
-	}
-	return out, nil
+		return emit(tx)
+	})
 }
 
 func validateHeader(header []string) error {
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
 )
 
 var _ = Describe("CSVParser", func() {
@@ -257,4 +258,51 @@ invalid-date,1000,Salary`
 			Expect(transactions[0].Content).To(Equal(`Salary with "quotes" and, commas`))
 		})
 	})
+
+	Context("when parsing with a dialect", func() {
+		It("should read semicolon-separated exports with a preamble, comments and a totals row", func() {
+			csvParser.Dialect = parser.CSVDialect{Comma: ';', Comment: '#', SkipLines: 2, SkipTrailing: 1}
+			csvContent := "Kontoauszug;Girokonto\nExport vom 2025-02-01\n" +
+				"date;amount;content\n" +
+				"# pending bookings follow the booked ones\n" +
+				"2025/01/01;1000;Salary\n" +
+				"2025/01/05;-200;\"Groceries; weekly\"\n" +
+				";800;Total\n"
+
+			transactions, err := csvParser.Parse(ctx, strings.NewReader(csvContent))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(2))
+			Expect(transactions[1].Content).To(Equal("Groceries; weekly"))
+		})
+
+		It("should report line numbers of the file, counting skipped and comment lines", func() {
+			csvParser.Dialect = parser.CSVDialect{Comment: '#', SkipLines: 1}
+			csvContent := "Exported transactions\ndate,amount,content\n# note\n2025/01/01,ten,Salary\n"
+
+			_, err := csvParser.Parse(ctx, strings.NewReader(csvContent))
+
+			Expect(err).To(MatchError(ContainSubstring("line 4:")))
+		})
+
+		It("should accept stray quotes with lazy quotes", func() {
+			csvContent := "date,amount,content\n2025/01/01,1000,Joe's \"Diner\n"
+
+			_, err := csvParser.Parse(ctx, strings.NewReader(csvContent))
+			Expect(err).To(HaveOccurred())
+
+			csvParser.Dialect.LazyQuotes = true
+			transactions, err := csvParser.Parse(ctx, strings.NewReader(csvContent))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions[0].Content).To(Equal(`Joe's "Diner`))
+		})
+
+		It("should reject invalid dialects", func() {
+			csvParser.Dialect = parser.CSVDialect{Comma: ';', Comment: ';'}
+
+			_, err := csvParser.Parse(ctx, strings.NewReader("date;amount;content\n"))
+
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
 })
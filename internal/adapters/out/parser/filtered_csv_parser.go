@@ -2,7 +2,6 @@ package parser
 
 import (
 	"context"
-	"encoding/csv"
 	"fmt"
 	"io"
 	"strconv"
@@ -14,7 +13,9 @@ import (
 )
 
 // FilteredCSVParser provides memory-efficient CSV parsing with early filtering
-type FilteredCSVParser struct{}
+type FilteredCSVParser struct {
+	Dialect CSVDialect
+}
 
 func NewFilteredCSV() *FilteredCSVParser {
 	return &FilteredCSVParser{}
@@ -28,54 +29,33 @@ const (
 
 // ParseWithFilter parses CSV and filters transactions during parsing to reduce memory usage
 func (p *FilteredCSVParser) ParseWithFilter(ctx context.Context, r io.Reader, filterFunc func(domain.Transaction) bool) ([]domain.Transaction, error) {
-	reader := csv.NewReader(r)
-	reader.FieldsPerRecord = -1
-	reader.TrimLeadingSpace = true
-
-	// Optimize buffer size for large files
-	reader.ReuseRecord = true
-
-	header, err := reader.Read()
+	var transactions []domain.Transaction
+	err := p.StreamWithFilter(ctx, r, filterFunc, func(transaction domain.Transaction) error {
+		transactions = append(transactions, transaction)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("read header: %w", err)
-	}
-	if err := streamingValidateHeader(header); err != nil {
 		return nil, err
 	}
+	return transactions, nil
+}
 
-	var (
-		transactions []domain.Transaction
-		rowIndex     = 2
-	)
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("read record at line %d: %w", rowIndex, err)
-		}
-
+// StreamWithFilter parses CSV and hands every transaction matching the filter to emit
+// as soon as it is read, without accumulating transactions in memory
+func (p *FilteredCSVParser) StreamWithFilter(ctx context.Context, r io.Reader, filterFunc func(domain.Transaction) bool, emit func(domain.Transaction) error) error {
+	// Records are reused to keep allocations down on large files
+	return p.Dialect.readRecords(ctx, r, true, streamingValidateHeader, func(record []string, line int) error {
 		transaction, err := streamingParseRecord(record)
 		if err != nil {
-			return nil, fmt.Errorf("line %d: %w", rowIndex, err)
+			return fmt.Errorf("line %d: %w", line, err)
 		}
 
-		// Early filtering - only add to result if it matches the filter
+		// Early filtering - only emit if it matches the filter
 		if filterFunc(transaction) {
-			transactions = append(transactions, transaction)
+			return emit(transaction)
 		}
-
-		rowIndex++
-	}
-	return transactions, nil
+		return nil
+	})
 }
 
 // ParseWithPeriodFilter parses CSV and filters by year/month during parsing
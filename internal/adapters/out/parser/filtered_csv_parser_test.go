@@ -2,6 +2,7 @@ package parser_test
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
@@ -130,6 +131,61 @@ var _ = Describe("FilteredCSVParser", func() {
 		})
 	})
 
+	Context("when streaming with a filter", func() {
+		It("should emit matching transactions in input order", func() {
+			csvContent := `date,amount,content
+2025/01/03,2000,Bonus
+2025/02/01,-500,Next Month
+2025/01/01,1000,Salary`
+			reader := strings.NewReader(csvContent)
+
+			var emitted []string
+			err := filteredParser.StreamWithFilter(ctx, reader, func(transaction domain.Transaction) bool {
+				return transaction.Date.Month() == time.January
+			}, func(transaction domain.Transaction) error {
+				emitted = append(emitted, transaction.Content)
+				return nil
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(emitted).To(Equal([]string{"Bonus", "Salary"}))
+		})
+
+		It("should stop and return the emit error", func() {
+			csvContent := `date,amount,content
+2025/01/01,1000,Salary
+2025/01/02,2000,Bonus`
+			reader := strings.NewReader(csvContent)
+			emitErr := errors.New("write failed")
+
+			calls := 0
+			err := filteredParser.StreamWithFilter(ctx, reader, func(domain.Transaction) bool {
+				return true
+			}, func(domain.Transaction) error {
+				calls++
+				return emitErr
+			})
+
+			Expect(err).To(MatchError(emitErr))
+			Expect(calls).To(Equal(1))
+		})
+	})
+
+	Context("when parsing with a dialect", func() {
+		It("should skip the preamble and trailing rows while streaming", func() {
+			filteredParser.Dialect = parser.CSVDialect{Comma: '\t', SkipLines: 1, SkipTrailing: 2}
+			csvContent := "Account 1234\ndate\tamount\tcontent\n" +
+				"2025/01/01\t1000\tSalary\n2025/02/01\t1000\tSalary\n2025/01/05\t-200\tGroceries\n" +
+				"\t\t\nTotal\t1800\t\n"
+
+			transactions, err := filteredParser.ParseWithPeriodFilter(ctx, strings.NewReader(csvContent), 2025, 1)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(2))
+			Expect(transactions[1].Content).To(Equal("Groceries"))
+		})
+	})
+
 	Context("when handling invalid CSV data", func() {
 		It("should return error for invalid headers", func() {
 			csvContent := `invalid,header,format
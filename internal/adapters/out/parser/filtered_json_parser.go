@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"mf-statement/internal/domain"
+	"mf-statement/internal/util"
+)
+
+// FilteredJSONParser is the streaming counterpart of JSONParser: records are
+// filtered as they are decoded, so only matching transactions are kept in memory
+type FilteredJSONParser struct {
+	Parser *JSONParser
+}
+
+func NewFilteredJSON(parser *JSONParser) *FilteredJSONParser {
+	return &FilteredJSONParser{Parser: parser}
+}
+
+// ParseWithFilter parses JSON and filters transactions during parsing to reduce memory usage
+func (p *FilteredJSONParser) ParseWithFilter(ctx context.Context, r io.Reader, filterFunc func(domain.Transaction) bool) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := p.StreamWithFilter(ctx, r, filterFunc, func(transaction domain.Transaction) error {
+		transactions = append(transactions, transaction)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// StreamWithFilter hands every transaction matching the filter to emit as soon as it is decoded
+func (p *FilteredJSONParser) StreamWithFilter(ctx context.Context, r io.Reader, filterFunc func(domain.Transaction) bool, emit func(domain.Transaction) error) error {
+	return p.Parser.stream(ctx, r, func(transaction domain.Transaction) error {
+		if !filterFunc(transaction) {
+			return nil
+		}
+		return emit(transaction)
+	})
+}
+
+// ParseWithPeriodFilter parses JSON and filters by year/month during parsing
+func (p *FilteredJSONParser) ParseWithPeriodFilter(ctx context.Context, r io.Reader, year, month int) ([]domain.Transaction, error) {
+	return p.ParseWithFilter(ctx, r, func(transaction domain.Transaction) bool {
+		return transaction.Date.Year() == year && int(transaction.Date.Month()) == month
+	})
+}
+
+// ParseWithDateRangeFilter parses JSON and filters by date range during parsing
+func (p *FilteredJSONParser) ParseWithDateRangeFilter(ctx context.Context, r io.Reader, startDate, endDate time.Time) ([]domain.Transaction, error) {
+	return p.ParseWithFilter(ctx, r, func(transaction domain.Transaction) bool {
+		return util.Between(transaction.Date, startDate, endDate)
+	})
+}
@@ -0,0 +1,64 @@
+package parser_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+)
+
+const ndjsonTransactions = `{"date":"2024/12/31","amount":-100,"content":"December"}
+{"date":"2025/01/01","amount":1000,"content":"Salary"}
+{"date":"2025/01/05","amount":-200,"content":"Groceries"}
+{"date":"2025/02/01","amount":1000,"content":"February"}
+`
+
+var _ = Describe("FilteredJSONParser", func() {
+	var (
+		filteredParser *parser.FilteredJSONParser
+		ctx            context.Context
+	)
+
+	BeforeEach(func() {
+		filteredParser = parser.NewFilteredJSON(parser.NewJSON())
+		ctx = context.Background()
+	})
+
+	It("should keep only the transactions of the period", func() {
+		transactions, err := filteredParser.ParseWithPeriodFilter(ctx, strings.NewReader(ndjsonTransactions), 2025, 1)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(2))
+		Expect(transactions[0].Content).To(Equal("Salary"))
+		Expect(transactions[1].Content).To(Equal("Groceries"))
+	})
+
+	It("should keep only the transactions of the date range", func() {
+		start := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		transactions, err := filteredParser.ParseWithDateRangeFilter(ctx, strings.NewReader(ndjsonTransactions), start, end)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(2))
+	})
+
+	It("should stop streaming when emit fails", func() {
+		stop := errors.New("stop")
+		emitted := 0
+
+		err := filteredParser.StreamWithFilter(ctx, strings.NewReader(ndjsonTransactions), func(domain.Transaction) bool { return true }, func(domain.Transaction) error {
+			emitted++
+			return stop
+		})
+
+		Expect(err).To(MatchError(stop))
+		Expect(emitted).To(Equal(1))
+	})
+})
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"mf-statement/internal/domain"
+)
+
+// JSONFields are the dotted paths of the transaction fields within each record,
+// e.g. "amount.value" for {"amount": {"value": 1250}}
+type JSONFields struct {
+	Date     string
+	Amount   string
+	Content  string
+	Category string
+	ID       string
+}
+
+func DefaultJSONFields() JSONFields {
+	return JSONFields{Date: "date", Amount: "amount", Content: "content", Category: "category", ID: "id"}
+}
+
+// JSONAmountUnit tells whether JSON amounts are minor units, like the CSV, or decimal major units
+type JSONAmountUnit string
+
+const (
+	JSONAmountMinor JSONAmountUnit = "minor"
+	JSONAmountMajor JSONAmountUnit = "major"
+)
+
+// jsonDateLayouts are tried in order when no date format is configured
+var jsonDateLayouts = []string{domain.CSVDateLayout, time.DateOnly, time.RFC3339Nano}
+
+// JSONParser reads transactions from a JSON array of records or from newline-delimited
+// JSON, one record per line. Records are decoded one at a time, so large arrays are
+// not loaded into memory unless Root points into an enclosing object.
+type JSONParser struct {
+	Fields JSONFields
+	// Root is the dotted path of the records array within an enclosing object,
+	// e.g. "data.transactions"; empty when the input is the array or NDJSON itself
+	Root string
+	// DateFormat is the Go layout of dates; empty accepts 2006/01/02, 2006-01-02 and RFC 3339
+	DateFormat string
+	AmountUnit JSONAmountUnit
+	// MinorUnits converts major unit amounts
+	MinorUnits int
+}
+
+func NewJSON() *JSONParser {
+	return &JSONParser{Fields: DefaultJSONFields(), AmountUnit: JSONAmountMinor, MinorUnits: DefaultMinorUnits}
+}
+
+// LooksLikeJSON reports whether the start of an input is a JSON array or object
+func LooksLikeJSON(header []byte) bool {
+	header = bytes.TrimSpace(bytes.TrimPrefix(header, []byte("\uFEFF")))
+	return bytes.HasPrefix(header, []byte("[")) || bytes.HasPrefix(header, []byte("{"))
+}
+
+func (p *JSONParser) Parse(ctx context.Context, r io.Reader) ([]domain.Transaction, error) {
+	var out []domain.Transaction
+	err := p.stream(ctx, r, func(tx domain.Transaction) error {
+		out = append(out, tx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Stream hands each transaction to emit as its record is decoded
+func (p *JSONParser) Stream(ctx context.Context, r io.Reader, emit func(domain.Transaction) error) error {
+	return p.stream(ctx, r, emit)
+}
+
+// stream decodes the records in input order and hands each transaction to emit
+func (p *JSONParser) stream(ctx context.Context, r io.Reader, emit func(domain.Transaction) error) error {
+	buffered := bufio.NewReader(r)
+	if bom, _ := buffered.Peek(3); bytes.Equal(bom, []byte("\uFEFF")) {
+		_, _ = buffered.Discard(3)
+	}
+	decoder := json.NewDecoder(buffered)
+	decoder.UseNumber()
+
+	if p.Root != "" {
+		return p.streamRoot(ctx, decoder, emit)
+	}
+
+	first, err := firstNonSpace(buffered)
+	if err != nil {
+		return fmt.Errorf("read JSON: %w", err)
+	}
+	if first == '[' {
+		if _, err := decoder.Token(); err != nil {
+			return domain.NewParseError("failed to read JSON array", err)
+		}
+	}
+
+	for index := 1; ; index++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if first == '[' && !decoder.More() {
+			break
+		}
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) && first != '[' {
+				break
+			}
+			return fmt.Errorf("record %d: %w", index, domain.NewParseError("invalid JSON record", err))
+		}
+		if err := p.emitRecord(record, index, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamRoot decodes the enclosing document and walks the records array at Root
+func (p *JSONParser) streamRoot(ctx context.Context, decoder *json.Decoder, emit func(domain.Transaction) error) error {
+	var document map[string]interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return domain.NewParseError("failed to decode JSON document", err)
+	}
+	value, ok := lookupPath(document, p.Root)
+	records, isArray := value.([]interface{})
+	if !ok || !isArray {
+		return domain.NewParseError(fmt.Sprintf("no records array at %q", p.Root), nil)
+	}
+
+	for i, value := range records {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		record, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("record %d: %w", i+1, domain.NewParseError("record is not an object", nil))
+		}
+		if err := p.emitRecord(record, i+1, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *JSONParser) emitRecord(record map[string]interface{}, index int, emit func(domain.Transaction) error) error {
+	tx, err := p.parseRecord(record)
+	if err != nil {
+		return fmt.Errorf("record %d: %w", index, err)
+	}
+	return emit(tx)
+}
+
+func (p *JSONParser) parseRecord(record map[string]interface{}) (domain.Transaction, error) {
+	dateStr, ok := p.stringField(record, p.Fields.Date)
+	if !ok || dateStr == "" {
+		return domain.Transaction{}, domain.NewValidationError("missing date", map[string]interface{}{"path": p.Fields.Date})
+	}
+	date, err := p.parseDate(dateStr)
+	if err != nil {
+		return domain.Transaction{}, domain.NewParseError(fmt.Sprintf("failed to parse date: %s", dateStr), err)
+	}
+
+	amountStr, ok := p.stringField(record, p.Fields.Amount)
+	if !ok || amountStr == "" {
+		return domain.Transaction{}, domain.NewValidationError("missing amount", map[string]interface{}{"path": p.Fields.Amount})
+	}
+	amount, err := p.parseAmount(amountStr)
+	if err != nil {
+		return domain.Transaction{}, domain.NewParseError(fmt.Sprintf("failed to parse amount: %s", amountStr), err)
+	}
+
+	content, _ := p.stringField(record, p.Fields.Content)
+	tx, err := domain.NewTransaction(date, amount, content)
+	if err != nil {
+		return domain.Transaction{}, domain.NewValidationError("missing content", map[string]interface{}{"path": p.Fields.Content})
+	}
+	tx.Category, _ = p.stringField(record, p.Fields.Category)
+	tx.ID, _ = p.stringField(record, p.Fields.ID)
+	return tx, nil
+}
+
+// stringField returns the scalar at path as a string; numbers keep their JSON spelling
+func (p *JSONParser) stringField(record map[string]interface{}, path string) (string, bool) {
+	if path == "" {
+		return "", false
+	}
+	value, ok := lookupPath(record, path)
+	if !ok {
+		return "", false
+	}
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v), true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+func (p *JSONParser) parseDate(value string) (time.Time, error) {
+	layouts := jsonDateLayouts
+	if p.DateFormat != "" {
+		layouts = []string{p.DateFormat}
+	}
+	var err error
+	for _, layout := range layouts {
+		var date time.Time
+		if date, err = time.Parse(layout, value); err == nil {
+			// A timestamp counts on the calendar day of its own offset
+			return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func (p *JSONParser) parseAmount(value string) (int64, error) {
+	if p.AmountUnit == JSONAmountMajor {
+		return ParseDecimalAmount(value, p.MinorUnits)
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// lookupPath follows a dotted path through nested objects
+func lookupPath(value interface{}, path string) (interface{}, bool) {
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func firstNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\n' && b != '\r' {
+			return b, r.UnreadByte()
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package parser_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("JSONParser", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	DescribeTable("should read arrays and newline-delimited records",
+		func(content string) {
+			transactions, err := parser.NewJSON().Parse(ctx, strings.NewReader(content))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(Equal([]domain.Transaction{
+				{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 1000, Content: "Salary", Category: "income", ID: "t1"},
+				{Date: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), Amount: -200, Content: "Groceries"},
+			}))
+		},
+		Entry("array", `[
+  {"date": "2025/01/01", "amount": 1000, "content": "Salary", "category": "income", "id": "t1"},
+  {"date": "2025-01-05", "amount": "-200", "content": "Groceries"}
+]`),
+		Entry("NDJSON", "{\"date\":\"2025/01/01\",\"amount\":1000,\"content\":\"Salary\",\"category\":\"income\",\"id\":\"t1\"}\n"+
+			"{\"date\":\"2025-01-05T23:30:00-08:00\",\"amount\":-200,\"content\":\"Groceries\"}\n"),
+		Entry("byte order mark", "\uFEFF[{\"date\":\"2025/01/01\",\"amount\":1000,\"content\":\"Salary\",\"category\":\"income\",\"id\":\"t1\"},"+
+			"{\"date\":\"2025/01/05\",\"amount\":-200,\"content\":\"Groceries\"}]"),
+	)
+
+	It("should follow configured field paths within an enclosing document", func() {
+		jsonParser := parser.NewJSON()
+		jsonParser.Root = "data.items"
+		jsonParser.Fields.Date = "posted_at"
+		jsonParser.Fields.Amount = "amount.value"
+		jsonParser.Fields.Content = "merchant.name"
+		jsonParser.DateFormat = "02.01.2006"
+		jsonParser.AmountUnit = parser.JSONAmountMajor
+		content := `{"data": {"items": [{"posted_at": "09.01.2025", "amount": {"value": -12.34, "currency": "EUR"}, "merchant": {"name": "Bakery"}}]}}`
+
+		transactions, err := jsonParser.Parse(ctx, strings.NewReader(content))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(Equal([]domain.Transaction{
+			{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -1234, Content: "Bakery"},
+		}))
+	})
+
+	It("should reject decimal amounts in minor units", func() {
+		_, err := parser.NewJSON().Parse(ctx, strings.NewReader(`[{"date":"2025/01/01","amount":12.5,"content":"Salary"}]`))
+
+		Expect(err).To(MatchError(ContainSubstring("record 1")))
+		Expect(err).To(MatchError(ContainSubstring("failed to parse amount: 12.5")))
+	})
+
+	It("should report the record with a missing field", func() {
+		content := "{\"date\":\"2025/01/01\",\"amount\":1,\"content\":\"a\"}\n{\"date\":\"2025/01/02\",\"content\":\"b\"}\n"
+
+		_, err := parser.NewJSON().Parse(ctx, strings.NewReader(content))
+
+		Expect(err).To(MatchError(ContainSubstring("record 2")))
+		Expect(err).To(MatchError(ContainSubstring("missing amount")))
+	})
+
+	It("should report malformed JSON", func() {
+		_, err := parser.NewJSON().Parse(ctx, strings.NewReader(`[{"date":"2025/01/01",`))
+
+		Expect(err).To(MatchError(ContainSubstring("invalid JSON record")))
+	})
+
+	It("should reject a root that is not an array", func() {
+		jsonParser := parser.NewJSON()
+		jsonParser.Root = "data"
+
+		_, err := jsonParser.Parse(ctx, strings.NewReader(`{"data": {"items": []}}`))
+
+		Expect(err).To(MatchError(ContainSubstring(`no records array at "data"`)))
+	})
+
+	It("should be recognised from its content", func() {
+		Expect(parser.LooksLikeJSON([]byte("\uFEFF  [{\"date\":\"2025/01/01\"}]"))).To(BeTrue())
+		Expect(parser.LooksLikeJSON([]byte("date,amount,content\n"))).To(BeFalse())
+	})
+})
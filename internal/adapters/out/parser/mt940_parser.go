@@ -0,0 +1,296 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"mf-statement/internal/domain"
+)
+
+// MT940Parser reads SWIFT MT940 customer statements. A file may hold several
+// statements, each starting with a :20: field; both the plain export and the
+// SWIFT message with {1:}{2:}{4: blocks are accepted.
+type MT940Parser struct {
+	// Reconcile fails parsing when a statement's balances do not match its entries
+	Reconcile bool
+}
+
+func NewMT940() *MT940Parser { return &MT940Parser{} }
+
+const mt940DateLayout = "060102"
+
+var (
+	mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+	// :61: value date, optional entry date, mark, funds code, amount, type and references
+	mt940Entry = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([A-Z]\w{3})?([^/\n]*)(?://([^\n]*))?`)
+	// :60F:, :62F: and their intermediate variants: mark, date, currency and amount
+	mt940Balance = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d+,\d*)`)
+	// German banks structure :86: as ?nn subfields
+	mt940Subfield = regexp.MustCompile(`\?(\d{2})`)
+)
+
+// LooksLikeMT940 reports whether the start of an input is an MT940 statement
+func LooksLikeMT940(header []byte) bool {
+	header = bytes.TrimSpace(bytes.TrimPrefix(header, []byte("\uFEFF")))
+	return bytes.HasPrefix(header, []byte(":20:")) ||
+		bytes.HasPrefix(header, []byte("{1:")) ||
+		(bytes.HasPrefix(header, []byte(":940:")) && bytes.Contains(header, []byte(":20:")))
+}
+
+type mt940Field struct {
+	tag   string
+	value string
+	line  int
+}
+
+func (p *MT940Parser) Parse(ctx context.Context, r io.Reader) ([]domain.Transaction, error) {
+	statements, err := p.ParseStatements(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return flattenStatements(statements, p.Reconcile)
+}
+
+// ParseStatements returns every statement of the file with its reported balances
+func (p *MT940Parser) ParseStatements(ctx context.Context, r io.Reader) ([]domain.BankStatement, error) {
+	fields, err := readMT940Fields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		out       []domain.BankStatement
+		statement *domain.BankStatement
+		entry     *domain.Transaction
+		entryLine int
+	)
+	finishEntry := func() error {
+		if entry == nil {
+			return nil
+		}
+		if entry.Content == "" {
+			return domain.NewValidationError(fmt.Sprintf("line %d: entry has no description", entryLine), nil)
+		}
+		statement.Transactions = append(statement.Transactions, *entry)
+		entry = nil
+		return nil
+	}
+
+	for _, field := range fields {
+		if field.tag == "20" {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			if err := finishEntry(); err != nil {
+				return nil, err
+			}
+			if statement != nil {
+				out = append(out, *statement)
+			}
+			statement = &domain.BankStatement{ID: field.value}
+			continue
+		}
+		if statement == nil {
+			return nil, domain.NewParseError(fmt.Sprintf("line %d: field :%s: before the :20: statement reference", field.line, field.tag), nil)
+		}
+
+		switch field.tag {
+		case "25":
+			statement.Account = field.value
+		case "60F", "60M":
+			balance, currency, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", field.line, err)
+			}
+			statement.Opening, statement.Currency = &balance, currency
+		case "62F", "62M":
+			if err := finishEntry(); err != nil {
+				return nil, err
+			}
+			balance, _, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", field.line, err)
+			}
+			statement.Closing = &balance
+		case "61":
+			if err := finishEntry(); err != nil {
+				return nil, err
+			}
+			tx, err := parseMT940Entry(field.value, statement.Currency)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", field.line, err)
+			}
+			entry, entryLine = &tx, field.line
+		case "86":
+			if entry != nil {
+				if description := mt940Description(field.value); description != "" {
+					entry.Content = description
+				}
+			}
+		}
+	}
+
+	if statement == nil {
+		return nil, domain.NewParseError("not an MT940 statement: no :20: field", nil)
+	}
+	if err := finishEntry(); err != nil {
+		return nil, err
+	}
+	return append(out, *statement), nil
+}
+
+// readMT940Fields splits the file into tagged fields, joining continuation lines
+func readMT940Fields(r io.Reader) ([]mt940Field, error) {
+	scanner := bufio.NewScanner(r)
+	var (
+		fields     []mt940Field
+		lineNumber int
+	)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\uFEFF")
+		}
+		// SWIFT block headers precede the text block, which ends with -}
+		if i := strings.Index(line, "{4:"); i >= 0 {
+			line = line[i+len("{4:"):]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "-" || trimmed == "-}" || strings.HasPrefix(trimmed, "{") || trimmed == ":940:" {
+			continue
+		}
+
+		if m := mt940Tag.FindStringSubmatch(line); m != nil {
+			fields = append(fields, mt940Field{tag: m[1], value: strings.TrimSpace(line[len(m[0]):]), line: lineNumber})
+			continue
+		}
+		if len(fields) == 0 {
+			return nil, domain.NewParseError(fmt.Sprintf("line %d: expected an MT940 field", lineNumber), nil)
+		}
+		fields[len(fields)-1].value += "\n" + trimmed
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read MT940 at line %d: %w", lineNumber, err)
+	}
+	return fields, nil
+}
+
+func parseMT940Balance(value string) (domain.Balance, string, error) {
+	m := mt940Balance.FindStringSubmatch(value)
+	if m == nil {
+		return domain.Balance{}, "", domain.NewParseError(fmt.Sprintf("failed to parse balance: %s", value), nil)
+	}
+	date, err := time.Parse(mt940DateLayout, m[2])
+	if err != nil {
+		return domain.Balance{}, "", domain.NewParseError(fmt.Sprintf("failed to parse balance date: %s", m[2]), err)
+	}
+	amount, err := ParseDecimalAmount(m[4], MinorUnits(m[3]))
+	if err != nil {
+		return domain.Balance{}, "", domain.NewParseError(fmt.Sprintf("failed to parse balance amount: %s", m[4]), err)
+	}
+	if m[1] == "D" {
+		amount = -amount
+	}
+	return domain.Balance{Date: date, Amount: amount}, m[3], nil
+}
+
+func parseMT940Entry(value, currency string) (domain.Transaction, error) {
+	m := mt940Entry.FindStringSubmatch(value)
+	if m == nil {
+		return domain.Transaction{}, domain.NewParseError(fmt.Sprintf("failed to parse statement line: %s", strings.SplitN(value, "\n", 2)[0]), nil)
+	}
+
+	valueDate, err := time.Parse(mt940DateLayout, m[1])
+	if err != nil {
+		return domain.Transaction{}, domain.NewParseError(fmt.Sprintf("failed to parse value date: %s", m[1]), err)
+	}
+	date := valueDate
+	if m[2] != "" {
+		if date, err = mt940EntryDate(valueDate, m[2]); err != nil {
+			return domain.Transaction{}, domain.NewParseError(fmt.Sprintf("failed to parse entry date: %s", m[2]), err)
+		}
+	}
+
+	amount, err := ParseDecimalAmount(m[5], MinorUnits(currency))
+	if err != nil {
+		return domain.Transaction{}, domain.NewParseError(fmt.Sprintf("failed to parse amount: %s", m[5]), err)
+	}
+	// RC reverses a credit and so debits the account; RD reverses a debit
+	if m[3] == "D" || m[3] == "RC" {
+		amount = -amount
+	}
+
+	tx := domain.Transaction{Date: date, Amount: amount, ID: strings.TrimSpace(m[8])}
+	if reference := strings.TrimSpace(m[7]); tx.ID == "" && reference != "NONREF" {
+		tx.ID = reference
+	}
+	// Supplementary details on the line after the references describe the entry
+	// when no :86: field follows
+	if _, details, ok := strings.Cut(value, "\n"); ok {
+		tx.Content = strings.Join(strings.Fields(details), " ")
+	}
+	if tx.Content == "" {
+		tx.Content = m[6]
+	}
+	return tx, nil
+}
+
+// mt940EntryDate places the MMDD booking date in the year nearest to the value date,
+// since bookings around New Year may fall in the previous or next year
+func mt940EntryDate(valueDate time.Time, monthDay string) (time.Time, error) {
+	entry, err := time.Parse("0102", monthDay)
+	if err != nil {
+		return time.Time{}, err
+	}
+	date := time.Date(valueDate.Year(), entry.Month(), entry.Day(), 0, 0, 0, 0, time.UTC)
+	switch diff := date.Sub(valueDate); {
+	case diff > 183*24*time.Hour:
+		date = date.AddDate(-1, 0, 0)
+	case diff < -183*24*time.Hour:
+		date = date.AddDate(1, 0, 0)
+	}
+	return date, nil
+}
+
+// mt940Description reads the information to account owner. Structured ?nn
+// subfields give the counterparty name (?32, ?33), the purpose (?20 to ?29) and
+// the posting text (?00); anything else is free text.
+func mt940Description(value string) string {
+	value = strings.ReplaceAll(value, "\n", "")
+	if !mt940Subfield.MatchString(value) {
+		return strings.Join(strings.Fields(value), " ")
+	}
+
+	var name, purpose, postingText string
+	positions := mt940Subfield.FindAllStringSubmatchIndex(value, -1)
+	for i, pos := range positions {
+		end := len(value)
+		if i+1 < len(positions) {
+			end = positions[i+1][0]
+		}
+		code, text := value[pos[2]:pos[3]], value[pos[1]:end]
+		switch {
+		case code == "00":
+			postingText = text
+		case code >= "20" && code <= "29":
+			purpose += text
+		case code == "32" || code == "33":
+			name += text
+		}
+	}
+
+	description := joinContent(strings.TrimSpace(name), strings.Join(strings.Fields(purpose), " "))
+	if description == "" {
+		description = strings.TrimSpace(postingText)
+	}
+	return description
+}
@@ -0,0 +1,97 @@
+package parser_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+)
+
+const mt940 = `:20:STARTUMS
+:25:37040044/0532013000
+:28C:00001/001
+:60F:C241231EUR1000,00
+:61:2412310102C2000,00NTRFNONREF//BANK-1
+:86:166?00GUTSCHRIFT?20Salary?21 January?32ACME GMBH
+:61:250109D249,50NDDTCARD-77
+Card payment at Grocer
+:61:2501150115RD10,00NCHGNONREF
+:86:Fee refund
+:62F:C250131EUR2760,50
+-
+:20:STMT2
+:25:37040044/0532013000
+:60F:C250131EUR2760,50
+:62F:C250131EUR2760,50
+-
+`
+
+var _ = Describe("MT940Parser", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should map statement lines to transactions", func() {
+		transactions, err := parser.NewMT940().Parse(ctx, strings.NewReader(mt940))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(Equal([]domain.Transaction{
+			{Date: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), Amount: 200000, Content: "ACME GMBH - Salary January", ID: "BANK-1"},
+			{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -24950, Content: "Card payment at Grocer", ID: "CARD-77"},
+			{Date: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), Amount: 1000, Content: "Fee refund"},
+		}))
+	})
+
+	It("should carry the reported balances of every statement", func() {
+		statements, err := parser.NewMT940().ParseStatements(ctx, strings.NewReader(mt940))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statements).To(HaveLen(2))
+		Expect(statements[0].Account).To(Equal("37040044/0532013000"))
+		Expect(statements[0].Currency).To(Equal("EUR"))
+		Expect(statements[0].Opening).To(Equal(&domain.Balance{Date: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), Amount: 100000}))
+		Expect(statements[0].Closing.Amount).To(Equal(int64(276050)))
+		Expect(statements[1].Transactions).To(BeEmpty())
+
+		for _, statement := range statements {
+			Expect(statement.Reconcile()).To(Succeed())
+		}
+	})
+
+	It("should read SWIFT message blocks", func() {
+		message := "{1:F01BANKDEFFAXXX0000000000}{2:O9400000000000BANKDEFFAXXX00000000000000000000N}{4:\n" + mt940[:strings.Index(mt940, "-\n")] + "-}"
+
+		transactions, err := (&parser.MT940Parser{Reconcile: true}).Parse(ctx, strings.NewReader(message))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(3))
+	})
+
+	It("should fail reconciliation when balances do not match", func() {
+		content := strings.Replace(mt940, ":62F:C250131EUR2760,50", ":62F:C250131EUR2700,00", 1)
+
+		_, err := (&parser.MT940Parser{Reconcile: true}).Parse(ctx, strings.NewReader(content))
+
+		Expect(err).To(MatchError(ContainSubstring("statement STARTUMS does not reconcile")))
+	})
+
+	It("should report the failing line", func() {
+		content := strings.Replace(mt940, ":61:250109D249,50", ":61:250109X249,50", 1)
+
+		_, err := parser.NewMT940().Parse(ctx, strings.NewReader(content))
+
+		Expect(err).To(MatchError(ContainSubstring("line 7")))
+	})
+
+	It("should be recognised from its content", func() {
+		Expect(parser.LooksLikeMT940([]byte(mt940))).To(BeTrue())
+		Expect(parser.LooksLikeMT940([]byte("date,amount,content\n"))).To(BeFalse())
+	})
+})
@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"mf-statement/internal/domain"
+)
+
+// OFXParser reads the STMTTRN entries of OFX and QFX bank statements. OFX 1.x is
+// SGML where leaf elements are not closed, OFX 2.x is XML; both are read by the
+// same tag scanner since only the element values matter.
+type OFXParser struct{}
+
+func NewOFX() *OFXParser { return &OFXParser{} }
+
+const ofxDateLayout = "20060102"
+
+// LooksLikeOFX reports whether the start of an input is an OFX document
+func LooksLikeOFX(header []byte) bool {
+	header = bytes.TrimPrefix(header, []byte("\uFEFF"))
+	header = bytes.ToUpper(bytes.TrimSpace(header))
+	return bytes.HasPrefix(header, []byte("OFXHEADER")) ||
+		bytes.HasPrefix(header, []byte("<OFX>")) ||
+		(bytes.HasPrefix(header, []byte("<?XML")) && bytes.Contains(header, []byte("<?OFX")))
+}
+
+func (p *OFXParser) Parse(ctx context.Context, r io.Reader) ([]domain.Transaction, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read OFX: %w", err)
+	}
+
+	start := bytes.Index(bytes.ToUpper(content), []byte("<OFX>"))
+	if start < 0 {
+		return nil, domain.NewParseError("not an OFX document: <OFX> element not found", nil)
+	}
+
+	var (
+		out      []domain.Transaction
+		currency string
+		current  map[string]string
+		index    int
+	)
+	scanner := ofxScanner{content: content[start:]}
+	for {
+		tag, value, ok := scanner.next()
+		if !ok {
+			break
+		}
+
+		switch tag {
+		case "STMTTRN":
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			index++
+			current = map[string]string{}
+		case "/STMTTRN":
+			if current == nil {
+				continue
+			}
+			tx, err := parseOFXTransaction(current, currency)
+			if err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", index, err)
+			}
+			out = append(out, tx)
+			current = nil
+		case "CURDEF":
+			currency = value
+		default:
+			if current != nil && value != "" && !strings.HasPrefix(tag, "/") {
+				current[tag] = value
+			}
+		}
+	}
+
+	if current != nil {
+		return nil, domain.NewParseError(fmt.Sprintf("transaction %d: STMTTRN is not closed", index), nil)
+	}
+	return out, nil
+}
+
+func parseOFXTransaction(fields map[string]string, currency string) (domain.Transaction, error) {
+	posted := fields["DTPOSTED"]
+	if len(posted) < len(ofxDateLayout) {
+		return domain.Transaction{}, domain.NewParseError(fmt.Sprintf("failed to parse DTPOSTED: %q", posted), nil)
+	}
+	// Time of day and time zone, as in 20250105120000.000[-5:EST], do not change the booking day
+	date, err := time.Parse(ofxDateLayout, posted[:len(ofxDateLayout)])
+	if err != nil {
+		return domain.Transaction{}, domain.NewParseError(fmt.Sprintf("failed to parse DTPOSTED: %s", posted), err)
+	}
+
+	amount, err := ParseDecimalAmount(fields["TRNAMT"], MinorUnits(currency))
+	if err != nil {
+		return domain.Transaction{}, domain.NewParseError(fmt.Sprintf("failed to parse TRNAMT: %s", fields["TRNAMT"]), err)
+	}
+
+	tx, err := domain.NewTransaction(date, amount, ofxContent(fields))
+	if err != nil {
+		return domain.Transaction{}, domain.NewValidationError("transaction has no NAME, MEMO or TRNTYPE", map[string]interface{}{
+			"fitid": fields["FITID"],
+		})
+	}
+	tx.ID = fields["FITID"]
+	return tx, nil
+}
+
+// ofxContent describes a transaction by its payee and memo, falling back to the
+// transaction type for entries such as fees that carry neither
+func ofxContent(fields map[string]string) string {
+	if content := joinContent(fields["NAME"], fields["MEMO"]); content != "" {
+		return content
+	}
+	return fields["TRNTYPE"]
+}
+
+// joinContent combines a payee and a memo, either of which may be missing
+func joinContent(name, memo string) string {
+	switch {
+	case name != "" && memo != "" && !strings.EqualFold(name, memo):
+		return name + " - " + memo
+	case name != "":
+		return name
+	default:
+		return memo
+	}
+}
+
+// ofxScanner walks the elements of an OFX body, returning each tag name with the
+// text that follows it up to the next tag
+type ofxScanner struct {
+	content []byte
+	pos     int
+}
+
+func (s *ofxScanner) next() (tag, value string, ok bool) {
+	for {
+		open := bytes.IndexByte(s.content[s.pos:], '<')
+		if open < 0 {
+			return "", "", false
+		}
+		open += s.pos
+
+		if bytes.HasPrefix(s.content[open:], []byte("<!--")) {
+			end := bytes.Index(s.content[open:], []byte("-->"))
+			if end < 0 {
+				return "", "", false
+			}
+			s.pos = open + end + len("-->")
+			continue
+		}
+
+		end := bytes.IndexByte(s.content[open:], '>')
+		if end < 0 {
+			return "", "", false
+		}
+		end += open
+		tag = strings.ToUpper(strings.TrimSpace(string(s.content[open+1 : end])))
+
+		textEnd := bytes.IndexByte(s.content[end+1:], '<')
+		if textEnd < 0 {
+			textEnd = len(s.content) - end - 1
+		}
+		value = html.UnescapeString(strings.TrimSpace(string(s.content[end+1 : end+1+textEnd])))
+		s.pos = end + 1 + textEnd
+
+		if strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") {
+			continue
+		}
+		return tag, value, true
+	}
+}
@@ -0,0 +1,146 @@
+package parser_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+)
+
+const ofxSGML = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:1252
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20250201</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>1
+<STMTRS>
+<CURDEF>USD
+<BANKACCTFROM><BANKID>121000248<ACCTID>123456<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20250101
+<DTEND>20250131
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250105120000.000[-5:EST]
+<TRNAMT>2000.00
+<FITID>20250105001
+<NAME>ACME PAYROLL
+<MEMO>Salary
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250109
+<TRNAMT>-3.5
+<FITID>20250109001
+<NAME>Ben &amp; Jerry's
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>FEE
+<DTPOSTED>20250131
+<TRNAMT>-1
+<FITID>20250131001
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const ofxXML = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CURDEF>JPY</CURDEF>
+        <BANKTRANLIST>
+          <!-- card payments -->
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20250203</DTPOSTED>
+            <TRNAMT>-1500</TRNAMT>
+            <FITID>A-1</FITID>
+            <NAME>Coffee</NAME>
+            <MEMO>Coffee</MEMO>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+var _ = Describe("OFXParser", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should parse OFX 1.x SGML statements", func() {
+		transactions, err := parser.NewOFX().Parse(ctx, strings.NewReader(ofxSGML))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(Equal([]domain.Transaction{
+			{Date: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), Amount: 200000, Content: "ACME PAYROLL - Salary", ID: "20250105001"},
+			{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -350, Content: "Ben & Jerry's", ID: "20250109001"},
+			{Date: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), Amount: -100, Content: "FEE", ID: "20250131001"},
+		}))
+	})
+
+	It("should parse OFX 2.x XML statements in the statement currency", func() {
+		transactions, err := parser.NewOFX().Parse(ctx, strings.NewReader(ofxXML))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(Equal([]domain.Transaction{
+			{Date: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), Amount: -1500, Content: "Coffee", ID: "A-1"},
+		}))
+	})
+
+	It("should report the failing transaction", func() {
+		content := strings.Replace(ofxSGML, "<TRNAMT>-3.5", "<TRNAMT>abc", 1)
+
+		_, err := parser.NewOFX().Parse(ctx, strings.NewReader(content))
+
+		Expect(err).To(MatchError(ContainSubstring("transaction 2")))
+		Expect(err).To(MatchError(ContainSubstring("TRNAMT")))
+	})
+
+	It("should reject invalid posting dates", func() {
+		content := strings.Replace(ofxXML, "<DTPOSTED>20250203", "<DTPOSTED>2025", 1)
+
+		_, err := parser.NewOFX().Parse(ctx, strings.NewReader(content))
+
+		Expect(err).To(MatchError(ContainSubstring("DTPOSTED")))
+	})
+
+	It("should reject documents without an OFX element", func() {
+		_, err := parser.NewOFX().Parse(ctx, strings.NewReader("date,amount,content\n"))
+
+		Expect(err).To(HaveOccurred())
+		Expect(domain.IsParseError(err)).To(BeTrue())
+	})
+
+	It("should reject truncated statements", func() {
+		content := ofxSGML[:strings.Index(ofxSGML, "</STMTTRN>")]
+
+		_, err := parser.NewOFX().Parse(ctx, strings.NewReader(content))
+
+		Expect(err).To(MatchError(ContainSubstring("not closed")))
+	})
+})
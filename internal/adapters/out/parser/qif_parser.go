@@ -0,0 +1,298 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"mf-statement/internal/domain"
+)
+
+// QIFDateFormat is the order of day, month and year in QIF dates, which Quicken
+// writes in the locale of the exporting machine
+type QIFDateFormat string
+
+const (
+	QIFDateMDY QIFDateFormat = "mdy"
+	QIFDateDMY QIFDateFormat = "dmy"
+	QIFDateYMD QIFDateFormat = "ymd"
+)
+
+// ParseQIFDateFormat validates a date format name
+func ParseQIFDateFormat(format string) (QIFDateFormat, error) {
+	switch QIFDateFormat(strings.ToLower(format)) {
+	case QIFDateMDY, "":
+		return QIFDateMDY, nil
+	case QIFDateDMY:
+		return QIFDateDMY, nil
+	case QIFDateYMD:
+		return QIFDateYMD, nil
+	default:
+		return "", domain.NewValidationError("invalid QIF date format", map[string]interface{}{
+			"format":  format,
+			"allowed": []QIFDateFormat{QIFDateMDY, QIFDateDMY, QIFDateYMD},
+		})
+	}
+}
+
+// qifRegisters are the account types whose records are plain transactions;
+// investment, category, class and memorized lists are skipped
+var qifRegisters = map[string]bool{
+	"BANK":  true,
+	"CCARD": true,
+	"CASH":  true,
+	"OTH A": true,
+	"OTH L": true,
+}
+
+// QIFParser reads Quicken Interchange Format exports. Split transactions become
+// one transaction per split so that each carries its own category.
+type QIFParser struct {
+	DateFormat QIFDateFormat
+	// MinorUnits is the number of decimals of the account currency, which QIF does not record
+	MinorUnits int
+}
+
+func NewQIF() *QIFParser {
+	return &QIFParser{DateFormat: QIFDateMDY, MinorUnits: DefaultMinorUnits}
+}
+
+// LooksLikeQIF reports whether the start of an input is a QIF export
+func LooksLikeQIF(header []byte) bool {
+	header = bytes.TrimPrefix(header, []byte("\uFEFF"))
+	header = bytes.ToUpper(bytes.TrimSpace(header))
+	return bytes.HasPrefix(header, []byte("!TYPE:")) ||
+		bytes.HasPrefix(header, []byte("!ACCOUNT")) ||
+		bytes.HasPrefix(header, []byte("!OPTION:"))
+}
+
+type qifSplit struct {
+	category string
+	memo     string
+	amount   string
+}
+
+type qifRecord struct {
+	line     int
+	date     string
+	amount   string
+	payee    string
+	memo     string
+	category string
+	splits   []qifSplit
+}
+
+func (p *QIFParser) Parse(ctx context.Context, r io.Reader) ([]domain.Transaction, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var (
+		out        []domain.Transaction
+		inRegister bool
+		record     *qifRecord
+		lineNumber int
+	)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\uFEFF")
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "!") {
+			header := strings.ToUpper(strings.TrimSpace(line))
+			section, isType := strings.CutPrefix(header, "!TYPE:")
+			inRegister = isType && qifRegisters[strings.TrimSpace(section)]
+			record = nil
+			continue
+		}
+		if !inRegister {
+			continue
+		}
+
+		if line[0] == '^' {
+			if record == nil {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			transactions, err := p.transactions(*record)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", record.line, err)
+			}
+			out = append(out, transactions...)
+			record = nil
+			continue
+		}
+
+		if record == nil {
+			record = &qifRecord{line: lineNumber}
+		}
+		value := strings.TrimSpace(line[1:])
+		switch line[0] {
+		case 'D':
+			record.date = value
+		case 'T', 'U':
+			record.amount = value
+		case 'P':
+			record.payee = value
+		case 'M':
+			record.memo = value
+		case 'L':
+			record.category = value
+		case 'S':
+			record.splits = append(record.splits, qifSplit{category: value})
+		case 'E':
+			if split := lastSplit(record); split != nil {
+				split.memo = value
+			}
+		case '$':
+			if split := lastSplit(record); split != nil {
+				split.amount = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read QIF at line %d: %w", lineNumber, err)
+	}
+	if record != nil {
+		return nil, domain.NewParseError(fmt.Sprintf("line %d: record is not terminated by ^", record.line), nil)
+	}
+	return out, nil
+}
+
+func lastSplit(record *qifRecord) *qifSplit {
+	if len(record.splits) == 0 {
+		return nil
+	}
+	return &record.splits[len(record.splits)-1]
+}
+
+func (p *QIFParser) transactions(record qifRecord) ([]domain.Transaction, error) {
+	date, err := p.parseDate(record.date)
+	if err != nil {
+		return nil, domain.NewParseError(fmt.Sprintf("failed to parse date: %s", record.date), err)
+	}
+	total, err := p.parseAmount(record.amount)
+	if err != nil {
+		return nil, domain.NewParseError(fmt.Sprintf("failed to parse amount: %s", record.amount), err)
+	}
+
+	if len(record.splits) == 0 {
+		tx, err := newQIFTransaction(date, total, joinContent(record.payee, record.memo), record.category)
+		if err != nil {
+			return nil, err
+		}
+		return []domain.Transaction{tx}, nil
+	}
+
+	var (
+		out []domain.Transaction
+		sum int64
+	)
+	for _, split := range record.splits {
+		amount, err := p.parseAmount(split.amount)
+		if err != nil {
+			return nil, domain.NewParseError(fmt.Sprintf("failed to parse split amount: %s", split.amount), err)
+		}
+		sum += amount
+
+		memo := split.memo
+		if memo == "" {
+			memo = record.memo
+		}
+		tx, err := newQIFTransaction(date, amount, joinContent(record.payee, memo), split.category)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tx)
+	}
+	if sum != total {
+		return nil, domain.NewParseError(fmt.Sprintf("split amounts total %d but the transaction total is %d", sum, total), nil)
+	}
+	return out, nil
+}
+
+func newQIFTransaction(date time.Time, amount int64, content, category string) (domain.Transaction, error) {
+	tx, err := domain.NewTransaction(date, amount, content)
+	if err != nil {
+		return domain.Transaction{}, domain.NewValidationError("transaction has no payee or memo", map[string]interface{}{
+			"date":   date.Format(domain.CSVDateLayout),
+			"amount": amount,
+		})
+	}
+	// A category may carry a class after a slash, as in Food:Groceries/Vacation
+	category, _, _ = strings.Cut(category, "/")
+	tx.Category = category
+	return tx, nil
+}
+
+func (p *QIFParser) parseAmount(amount string) (int64, error) {
+	if isThousandsGrouped(amount) {
+		amount = strings.ReplaceAll(amount, ",", "")
+	}
+	return ParseDecimalAmount(amount, p.MinorUnits)
+}
+
+// isThousandsGrouped reports whether every comma is followed by exactly three digits,
+// as in 1,234.56 or 1,500, rather than being a decimal comma as in 12,50
+func isThousandsGrouped(amount string) bool {
+	if !strings.Contains(amount, ",") {
+		return false
+	}
+	for _, group := range strings.Split(amount, ",")[1:] {
+		digits, _, _ := strings.Cut(group, ".")
+		if len(digits) != 3 || strings.Trim(digits, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDate reads the many spellings Quicken uses, such as 1/5/25, 01/05/2025,
+// 1/ 5'25 (an apostrophe marks a year after 2000) and 2025-01-05
+func (p *QIFParser) parseDate(value string) (time.Time, error) {
+	parts := strings.FieldsFunc(value, func(r rune) bool { return !unicode.IsDigit(r) })
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("expected day, month and year")
+	}
+
+	var yearPart, monthPart, dayPart string
+	switch {
+	case len(parts[0]) == 4 || p.DateFormat == QIFDateYMD:
+		yearPart, monthPart, dayPart = parts[0], parts[1], parts[2]
+	case p.DateFormat == QIFDateDMY:
+		dayPart, monthPart, yearPart = parts[0], parts[1], parts[2]
+	default:
+		monthPart, dayPart, yearPart = parts[0], parts[1], parts[2]
+	}
+
+	year, _ := strconv.Atoi(yearPart)
+	month, _ := strconv.Atoi(monthPart)
+	day, _ := strconv.Atoi(dayPart)
+	if len(yearPart) <= 2 {
+		if year < 70 || strings.Contains(value, "'") {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return time.Time{}, fmt.Errorf("day %d of month %d does not exist", day, month)
+	}
+	return date, nil
+}
@@ -0,0 +1,143 @@
+package parser_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+)
+
+const qifExport = `!Option:AutoSwitch
+!Account
+NChecking
+TBank
+^
+!Clear:AutoSwitch
+!Type:Bank
+D01/05/2025
+T1,000.00
+PACME Payroll
+MJanuary salary
+LIncome:Salary
+^
+D1/ 9'25
+T-120.50
+PCorner Market
+SFood:Groceries
+EWeekly shop
+$-100.50
+SHousehold/Home
+$-20.00
+^
+!Type:Cat
+NFood
+E
+^
+!Type:CCard
+D01/20/2025
+U-35.00
+T-35.00
+MBooks
+^
+`
+
+var _ = Describe("QIFParser", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should parse bank and credit card sections with splits", func() {
+		transactions, err := parser.NewQIF().Parse(ctx, strings.NewReader(qifExport))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(Equal([]domain.Transaction{
+			{Date: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), Amount: 100000, Content: "ACME Payroll - January salary", Category: "Income:Salary"},
+			{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -10050, Content: "Corner Market - Weekly shop", Category: "Food:Groceries"},
+			{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -2000, Content: "Corner Market", Category: "Household"},
+			{Date: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), Amount: -3500, Content: "Books"},
+		}))
+	})
+
+	DescribeTable("should read dates in the configured order",
+		func(format parser.QIFDateFormat, date string, expected time.Time) {
+			qif := &parser.QIFParser{DateFormat: format, MinorUnits: 2}
+			content := "!Type:Bank\nD" + date + "\nT-1.00\nPShop\n^\n"
+
+			transactions, err := qif.Parse(ctx, strings.NewReader(content))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions[0].Date).To(Equal(expected))
+		},
+		Entry("month first", parser.QIFDateMDY, "03/04/2025", time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)),
+		Entry("day first", parser.QIFDateDMY, "03/04/2025", time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC)),
+		Entry("year first", parser.QIFDateYMD, "2025.04.03", time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC)),
+		Entry("ISO dates whatever the order", parser.QIFDateDMY, "2025-04-03", time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC)),
+		Entry("two-digit year of the last century", parser.QIFDateMDY, "12/31/99", time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC)),
+	)
+
+	It("should convert amounts to the configured minor units", func() {
+		qif := &parser.QIFParser{DateFormat: parser.QIFDateMDY, MinorUnits: parser.MinorUnits("JPY")}
+
+		transactions, err := qif.Parse(ctx, strings.NewReader("!Type:Bank\nD01/05/2025\nT-1,500\nPCafe\n^\n"))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions[0].Amount).To(Equal(int64(-1500)))
+	})
+
+	It("should read decimal commas", func() {
+		transactions, err := parser.NewQIF().Parse(ctx, strings.NewReader("!Type:Bank\nD05.01.2025\nT-12,50\nPBäckerei\n^\n"))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions[0].Amount).To(Equal(int64(-1250)))
+	})
+
+	It("should reject splits that do not add up to the total", func() {
+		content := strings.Replace(qifExport, "$-20.00", "$-25.00", 1)
+
+		_, err := parser.NewQIF().Parse(ctx, strings.NewReader(content))
+
+		Expect(err).To(MatchError(ContainSubstring("line 14")))
+		Expect(err).To(MatchError(ContainSubstring("split amounts total -12550")))
+	})
+
+	It("should reject impossible dates", func() {
+		_, err := parser.NewQIF().Parse(ctx, strings.NewReader("!Type:Bank\nD02/30/2025\nT-1.00\nPShop\n^\n"))
+
+		Expect(err).To(MatchError(ContainSubstring("failed to parse date")))
+	})
+
+	It("should reject records without a terminator", func() {
+		_, err := parser.NewQIF().Parse(ctx, strings.NewReader("!Type:Bank\nD01/05/2025\nT-1.00\nPShop\n"))
+
+		Expect(err).To(MatchError(ContainSubstring("not terminated")))
+	})
+
+	It("should skip investment accounts", func() {
+		transactions, err := parser.NewQIF().Parse(ctx, strings.NewReader("!Type:Invst\nD01/05/2025\nNBuy\nYACME\nT-100.00\n^\n"))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(BeEmpty())
+	})
+
+	It("should be recognised from its content", func() {
+		Expect(parser.LooksLikeQIF([]byte(qifExport))).To(BeTrue())
+		Expect(parser.LooksLikeQIF([]byte("date,amount,content\n"))).To(BeFalse())
+	})
+})
+
+var _ = Describe("ParseQIFDateFormat", func() {
+	It("should default to month first and reject unknown orders", func() {
+		Expect(parser.ParseQIFDateFormat("")).To(Equal(parser.QIFDateMDY))
+		Expect(parser.ParseQIFDateFormat("DMY")).To(Equal(parser.QIFDateDMY))
+
+		_, err := parser.ParseQIFDateFormat("dd/mm/yyyy")
+		Expect(err).To(HaveOccurred())
+	})
+})
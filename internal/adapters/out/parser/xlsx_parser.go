@@ -0,0 +1,251 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"mf-statement/internal/domain"
+)
+
+// XLSXColumns are the header names of the transaction columns, matched case-insensitively
+type XLSXColumns struct {
+	Date     string
+	Amount   string
+	Content  string
+	Category string
+}
+
+func DefaultXLSXColumns() XLSXColumns {
+	return XLSXColumns{Date: colDate, Amount: colAmount, Content: colContent, Category: "category"}
+}
+
+// xlsxHeaderSearchRows bounds how far below titles and notes the header row may be
+const xlsxHeaderSearchRows = 50
+
+// XLSXParser reads transactions from an Excel workbook. The header row is searched
+// for among the first rows, so sheets may start with a title block. Errors name the
+// offending cell, e.g. Sheet1!B17.
+type XLSXParser struct {
+	// Sheet is the worksheet to read; empty reads the first sheet
+	Sheet   string
+	Columns XLSXColumns
+	// DateFormat is the Go layout of dates stored as text; date cells need none
+	DateFormat string
+	// AmountUnit tells whether amounts are minor units or major units with decimals
+	AmountUnit JSONAmountUnit
+	MinorUnits int
+}
+
+func NewXLSX() *XLSXParser {
+	return &XLSXParser{Columns: DefaultXLSXColumns(), AmountUnit: JSONAmountMinor, MinorUnits: DefaultMinorUnits}
+}
+
+// LooksLikeXLSX reports whether the start of an input is an Office Open XML package
+func LooksLikeXLSX(header []byte) bool {
+	return bytes.HasPrefix(header, []byte("PK\x03\x04"))
+}
+
+type xlsxLayout struct {
+	headerRow                       int
+	date, amount, content, category int
+}
+
+func (p *XLSXParser) Parse(ctx context.Context, r io.Reader) ([]domain.Transaction, error) {
+	workbook, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, domain.NewParseError("failed to open XLSX workbook", err)
+	}
+	defer workbook.Close()
+
+	sheet := p.Sheet
+	if sheet == "" {
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, domain.NewParseError("workbook has no sheets", nil)
+		}
+		sheet = sheets[0]
+	}
+	rows, err := workbook.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, domain.NewParseError(fmt.Sprintf("failed to read sheet %q", sheet), err)
+	}
+
+	props, err := workbook.GetWorkbookProps()
+	if err != nil {
+		return nil, domain.NewParseError("failed to read workbook properties", err)
+	}
+	date1904 := props.Date1904 != nil && *props.Date1904
+
+	layout, err := p.findHeader(rows)
+	if err != nil {
+		return nil, fmt.Errorf("sheet %s: %w", sheet, err)
+	}
+
+	var out []domain.Transaction
+	for i := layout.headerRow; i < len(rows); i++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		row := rows[i]
+		if isBlankRow(row) {
+			continue
+		}
+		tx, err := p.parseRow(row, layout, date1904)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cellRef(sheet, err.column, i+1), err.err)
+		}
+		out = append(out, tx)
+	}
+	return out, nil
+}
+
+// findHeader returns the position of the first row naming the date, amount and content columns
+func (p *XLSXParser) findHeader(rows [][]string) (xlsxLayout, error) {
+	for i, row := range rows {
+		if i >= xlsxHeaderSearchRows {
+			break
+		}
+		layout := xlsxLayout{headerRow: i + 1, date: -1, amount: -1, content: -1, category: -1}
+		for col, cell := range row {
+			switch {
+			case eq(cell, p.Columns.Date):
+				layout.date = col
+			case eq(cell, p.Columns.Amount):
+				layout.amount = col
+			case eq(cell, p.Columns.Content):
+				layout.content = col
+			case p.Columns.Category != "" && eq(cell, p.Columns.Category):
+				layout.category = col
+			}
+		}
+		if layout.date >= 0 && layout.amount >= 0 && layout.content >= 0 {
+			return layout, nil
+		}
+	}
+	return xlsxLayout{}, domain.NewParseError(fmt.Sprintf("header row with columns %q, %q and %q not found in the first %d rows",
+		p.Columns.Date, p.Columns.Amount, p.Columns.Content, xlsxHeaderSearchRows), nil)
+}
+
+// cellError carries the column of a failing cell so the caller can name it
+type cellError struct {
+	column int
+	err    error
+}
+
+func (p *XLSXParser) parseRow(row []string, layout xlsxLayout, date1904 bool) (domain.Transaction, *cellError) {
+	dateValue, amountValue, content := cell(row, layout.date), cell(row, layout.amount), cell(row, layout.content)
+
+	if dateValue == "" {
+		return domain.Transaction{}, &cellError{layout.date, domain.NewValidationError("empty date", nil)}
+	}
+	date, err := p.parseDate(dateValue, date1904)
+	if err != nil {
+		return domain.Transaction{}, &cellError{layout.date, domain.NewParseError(fmt.Sprintf("failed to parse date: %s", dateValue), err)}
+	}
+
+	if amountValue == "" {
+		return domain.Transaction{}, &cellError{layout.amount, domain.NewValidationError("empty amount", nil)}
+	}
+	amount, err := p.parseAmount(amountValue)
+	if err != nil {
+		return domain.Transaction{}, &cellError{layout.amount, domain.NewParseError(fmt.Sprintf("failed to parse amount: %s", amountValue), err)}
+	}
+
+	tx, err := domain.NewTransaction(date, amount, content)
+	if err != nil {
+		return domain.Transaction{}, &cellError{layout.content, domain.NewValidationError("empty content", nil)}
+	}
+	if layout.category >= 0 {
+		tx.Category = cell(row, layout.category)
+	}
+	return tx, nil
+}
+
+// parseDate converts date cells, which hold serial day numbers, and dates stored as text
+func (p *XLSXParser) parseDate(value string, date1904 bool) (time.Time, error) {
+	if serial, err := strconv.ParseFloat(value, 64); err == nil {
+		date, err := excelize.ExcelDateToTime(serial, date1904)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+
+	layouts := []string{domain.CSVDateLayout, time.DateOnly}
+	if p.DateFormat != "" {
+		layouts = []string{p.DateFormat}
+	}
+	var err error
+	for _, layout := range layouts {
+		var date time.Time
+		if date, err = time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// parseAmount converts numeric cells, which Excel stores as binary floating point,
+// by rounding to the minor unit; amounts stored as text are converted exactly
+func (p *XLSXParser) parseAmount(value string) (int64, error) {
+	units := 0
+	if p.AmountUnit == JSONAmountMajor {
+		units = p.MinorUnits
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		if isThousandsGrouped(value) {
+			value = strings.ReplaceAll(value, ",", "")
+		}
+		return ParseDecimalAmount(value, units)
+	}
+
+	scaled := math.Round(number * math.Pow10(units))
+	if math.Abs(scaled) >= math.MaxInt64 {
+		return 0, fmt.Errorf("amount %s out of range", value)
+	}
+	if units == 0 && math.Abs(scaled-number) > 1e-9 {
+		return 0, fmt.Errorf("amount %s is not a whole number of minor units", value)
+	}
+	return int64(scaled), nil
+}
+
+func cell(row []string, col int) string {
+	if col < 0 || col >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[col])
+}
+
+func isBlankRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// cellRef formats a sheet and zero-based column as an A1 reference such as Sheet1!B17
+func cellRef(sheet string, col, row int) string {
+	name, err := excelize.CoordinatesToCellName(col+1, row)
+	if err != nil {
+		return fmt.Sprintf("%s!R%dC%d", sheet, row, col+1)
+	}
+	if strings.ContainsAny(sheet, " '!") {
+		sheet = "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+	}
+	return sheet + "!" + name
+}
@@ -0,0 +1,126 @@
+package parser_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/xuri/excelize/v2"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+)
+
+// workbook builds an XLSX file with the rows on its first sheet, starting at A1
+func workbook(sheet string, rows ...[]interface{}) *bytes.Buffer {
+	f := excelize.NewFile()
+	defer f.Close()
+	if sheet != "Sheet1" {
+		Expect(f.SetSheetName("Sheet1", sheet)).To(Succeed())
+	}
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.SetSheetRow(sheet, cell, &row)).To(Succeed())
+	}
+	buf, err := f.WriteToBuffer()
+	Expect(err).NotTo(HaveOccurred())
+	return buf
+}
+
+var _ = Describe("XLSXParser", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should find the header below a title block and convert date cells", func() {
+		content := workbook("Sheet1",
+			[]interface{}{"Account statement"},
+			[]interface{}{"Generated", "2025-02-01"},
+			[]interface{}{},
+			[]interface{}{"Date", "Content", "Amount", "Category"},
+			[]interface{}{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "Salary", 1000, "income"},
+			[]interface{}{},
+			[]interface{}{"2025/01/05", "Groceries", "-200"},
+		)
+
+		transactions, err := parser.NewXLSX().Parse(ctx, content)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(Equal([]domain.Transaction{
+			{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 1000, Content: "Salary", Category: "income"},
+			{Date: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), Amount: -200, Content: "Groceries"},
+		}))
+	})
+
+	It("should read the configured sheet, columns and major unit amounts", func() {
+		f := excelize.NewFile()
+		_, err := f.NewSheet("Bookings")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.SetSheetRow("Bookings", "A1", &[]interface{}{"Booking Date", "Text", "Value"})).To(Succeed())
+		Expect(f.SetSheetRow("Bookings", "A2", &[]interface{}{"09.01.2025", "Bakery", -12.34})).To(Succeed())
+		Expect(f.SetSheetRow("Bookings", "A3", &[]interface{}{"10.01.2025", "Refund", "1,234.50"})).To(Succeed())
+		content, err := f.WriteToBuffer()
+		Expect(err).NotTo(HaveOccurred())
+
+		xlsxParser := parser.NewXLSX()
+		xlsxParser.Sheet = "Bookings"
+		xlsxParser.Columns = parser.XLSXColumns{Date: "booking date", Amount: "value", Content: "text"}
+		xlsxParser.DateFormat = "02.01.2006"
+		xlsxParser.AmountUnit = parser.JSONAmountMajor
+
+		transactions, err := xlsxParser.Parse(ctx, content)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(Equal([]domain.Transaction{
+			{Date: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), Amount: -1234, Content: "Bakery"},
+			{Date: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), Amount: 123450, Content: "Refund"},
+		}))
+	})
+
+	DescribeTable("should name the offending cell",
+		func(sheet string, row []interface{}, message string) {
+			content := workbook(sheet,
+				[]interface{}{"date", "amount", "content"},
+				[]interface{}{"2025/01/01", 100, "Coffee"},
+				row,
+			)
+
+			_, err := parser.NewXLSX().Parse(ctx, content)
+
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("bad amount", "Sheet1", []interface{}{"2025/01/02", "ten", "Tea"}, "Sheet1!B3: "),
+		Entry("fractional minor units", "Sheet1", []interface{}{"2025/01/02", 1.5, "Tea"}, "Sheet1!B3: "),
+		Entry("bad date", "Sheet1", []interface{}{"tomorrow", 100, "Tea"}, "Sheet1!A3: "),
+		Entry("empty content", "Sheet1", []interface{}{"2025/01/02", 100}, "Sheet1!C3: "),
+		Entry("quoted sheet name", "My Bank", []interface{}{"2025/01/02", "ten", "Tea"}, "'My Bank'!B3: "),
+	)
+
+	It("should be recognised from its content", func() {
+		Expect(parser.LooksLikeXLSX(workbook("Sheet1").Bytes())).To(BeTrue())
+		Expect(parser.LooksLikeXLSX([]byte("date,amount,content\n"))).To(BeFalse())
+	})
+
+	It("should report a missing header row", func() {
+		content := workbook("Sheet1", []interface{}{"when", "how much", "what"})
+
+		_, err := parser.NewXLSX().Parse(ctx, content)
+
+		Expect(err).To(MatchError(ContainSubstring("header row")))
+	})
+
+	It("should report an unknown sheet", func() {
+		xlsxParser := parser.NewXLSX()
+		xlsxParser.Sheet = "Missing"
+
+		_, err := xlsxParser.Parse(ctx, workbook("Sheet1", []interface{}{"date", "amount", "content"}))
+
+		Expect(err).To(HaveOccurred())
+		Expect(domain.IsParseError(err)).To(BeTrue())
+	})
+})
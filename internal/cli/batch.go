@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// DefaultFilenamePattern names batch statements like 2025-01.json
+const DefaultFilenamePattern = "{yyyy}-{mm}.json"
+
+// FilenameForPeriod expands the {yyyy}, {mm} and {period} (YYYYMM) placeholders of pattern
+func FilenameForPeriod(pattern string, period usecase.Period) string {
+	return strings.NewReplacer(
+		"{yyyy}", fmt.Sprintf("%04d", period.Year),
+		"{mm}", fmt.Sprintf("%02d", period.Month),
+		"{period}", fmt.Sprintf("%04d%02d", period.Year, period.Month),
+	).Replace(pattern)
+}
+
+// ValidateFilenamePattern ensures every period gets its own file inside the output directory
+func ValidateFilenamePattern(pattern string) error {
+	unique := strings.Contains(pattern, "{period}") ||
+		(strings.Contains(pattern, "{yyyy}") && strings.Contains(pattern, "{mm}"))
+	if !unique {
+		return domain.NewValidationError("filename pattern must contain {period} or both {yyyy} and {mm}", map[string]interface{}{
+			"pattern": pattern,
+		})
+	}
+	if filepath.IsAbs(pattern) || strings.Contains(filepath.ToSlash(pattern), "..") {
+		return domain.NewValidationError("filename pattern must be relative to the output directory", map[string]interface{}{
+			"pattern": pattern,
+		})
+	}
+	return nil
+}
+
+// BatchWriterFactory creates a writer per period inside outDir (a directory or s3:// prefix), using the same
+// format inference and file options as single statement outputs
+func BatchWriterFactory(outDir, pattern string, options OutputOptions) usecase.WriterFactory {
+	return func(period usecase.Period) (output.Writer, error) {
+		var path string
+		if objectstore.IsURI(outDir) {
+			path = strings.TrimSuffix(outDir, "/") + "/" + filepath.ToSlash(FilenameForPeriod(pattern, period))
+		} else {
+			path = filepath.Join(outDir, FilenameForPeriod(pattern, period))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return nil, domain.NewIOError("failed to create output directory", err)
+			}
+		}
+
+		options.Outputs = []string{path}
+		return options.CreateWriter()
+	}
+}
@@ -0,0 +1,101 @@
+package cli_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/objectstore/objectstoretest"
+	"mf-statement/internal/cli"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+var _ = Describe("Batch generation", func() {
+	Context("FilenameForPeriod", func() {
+		It("should expand placeholders", func() {
+			period := usecase.Period{Year: 2025, Month: 1}
+
+			Expect(cli.FilenameForPeriod(cli.DefaultFilenamePattern, period)).To(Equal("2025-01.json"))
+			Expect(cli.FilenameForPeriod("{yyyy}/stmt-{period}.csv", period)).To(Equal("2025/stmt-202501.csv"))
+		})
+	})
+
+	Context("ValidateFilenamePattern", func() {
+		It("should require a unique name per period", func() {
+			Expect(cli.ValidateFilenamePattern("{yyyy}.json")).NotTo(Succeed())
+			Expect(domain.IsValidationError(cli.ValidateFilenamePattern("statement.json"))).To(BeTrue())
+			Expect(cli.ValidateFilenamePattern("{period}.json")).To(Succeed())
+		})
+
+		It("should keep files inside the output directory", func() {
+			Expect(cli.ValidateFilenamePattern("../{period}.json")).NotTo(Succeed())
+			Expect(cli.ValidateFilenamePattern("/tmp/{period}.json")).NotTo(Succeed())
+		})
+	})
+
+	Context("BatchWriterFactory", func() {
+		It("should write statements below an s3:// prefix", func() {
+			server := objectstoretest.NewServer()
+			defer server.Close()
+
+			factory := cli.BatchWriterFactory("s3://statements/2025/", "{period}.json", cli.OutputOptions{S3: server.Config()})
+			writer, err := factory(usecase.Period{Year: 2025, Month: 3})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Write(context.Background(), domain.Statement{Period: "2025/03"})).To(Succeed())
+
+			content, ok := server.Get("statements", "2025/202503.json")
+			Expect(ok).To(BeTrue())
+			Expect(string(content)).To(ContainSubstring(`"period": "2025/03"`))
+		})
+	})
+
+	Context("generate --all-periods", func() {
+		var tempDir string
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "batch_cmd_test_*")
+			Expect(err).NotTo(HaveOccurred())
+			cli.NewRootCommand()
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tempDir)
+		})
+
+		It("should write one file per month", func(ctx SpecContext) {
+			csvPath := filepath.Join(tempDir, "transactions.csv")
+			Expect(os.WriteFile(csvPath, []byte(`date,amount,content
+2025/01/01,1000,Salary
+2025/02/05,-200,Groceries
+2025/01/09,-300,Transport
+`), 0644)).To(Succeed())
+			outDir := filepath.Join(tempDir, "statements")
+
+			cmd := cli.NewGenerateCommand()
+			cmd.SetArgs([]string{"--all-periods", "--csv", csvPath, "--out-dir", outDir, "--filename-pattern", "{yyyy}-{mm}.csv"})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			entries, err := os.ReadDir(outDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+
+			data, err := os.ReadFile(filepath.Join(outDir, "2025-01.csv"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("date,amount,content\n2025/01/09,-300,Transport\n2025/01/01,1000,Salary\n"))
+		}, SpecTimeout(5*time.Second))
+
+		It("should require --out-dir", func() {
+			cmd := cli.NewGenerateCommand()
+			cmd.SetArgs([]string{"--all-periods", "--csv", "transactions.csv"})
+
+			Expect(cmd.Execute()).NotTo(Succeed())
+		})
+	})
+})
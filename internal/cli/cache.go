@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"mf-statement/internal/adapters/cache"
+	"mf-statement/internal/usecase"
+)
+
+// CacheOptions collects the flags of the on-disk transaction index
+type CacheOptions struct {
+	Enabled bool
+	Dir     string
+	Verify  bool
+}
+
+// AddCacheFlags registers the flags caching parsed local inputs between runs
+func AddCacheFlags(flags *pflag.FlagSet, options *CacheOptions) {
+	flags.BoolVar(&options.Enabled, "cache", false, "Index parsed local inputs on disk so later runs read only the requested months instead of reparsing")
+	flags.StringVar(&options.Dir, "cache-dir", "", "Directory of the transaction indexes (default: mf-statement in the user cache directory)")
+	flags.BoolVar(&options.Verify, "cache-verify", false, "Hash inputs on every run instead of trusting an unchanged size and modification time")
+}
+
+// Wrap returns service answering from the transaction index when --cache is given.
+// The index is kept per input option set, since other options parse other transactions.
+func (o CacheOptions) Wrap(service usecase.TransactionService, inputOptions InputOptions) (usecase.TransactionService, error) {
+	if !o.Enabled {
+		return service, nil
+	}
+
+	dir := o.Dir
+	if dir == "" {
+		var err error
+		if dir, err = cache.DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	index := cache.NewFileIndex(dir)
+	index.VerifyContent = o.Verify
+
+	cached := usecase.NewCachedTransactionService(service, index, fmt.Sprintf("%+v", inputOptions))
+	cached.OnError = func(uri string, err error) {
+		logger.Warn("Transaction index unavailable, parsing the input", "input", uri, "error", err)
+	}
+	logger.Debug("Caching parsed inputs", "dir", dir)
+	return cached, nil
+}
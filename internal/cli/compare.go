@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+	"mf-statement/internal/util"
+)
+
+// Comparison report formats
+const (
+	CompareFormatTable = "table"
+	CompareFormatJSON  = "json"
+)
+
+func NewCompareCommand() *cobra.Command {
+	var (
+		periodArg     string
+		compareTo     string
+		csvPaths      []string
+		outPath       string
+		format        string
+		noClobber     bool
+		fileMode      string
+		sourceOptions SourceOptions
+		dbOptions     DatabaseOptions
+		inputOptions  InputOptions
+		storePath     string
+		cacheOptions  CacheOptions
+		dedupeMode    string
+		dedupeKey     []string
+		s3Config      objectstore.Config
+		verbose       bool
+		timeout       int
+		configOptions ConfigOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare a month with the previous month or the same month last year",
+		Long: `Totals a month and its comparison period and reports the change of income, expenditure,
+net, transaction counts and, when the transactions have categories, the spend per category.
+
+Amounts are minor units like the statements. Percentage changes are relative to the
+magnitude of the earlier value, n/a (null in JSON) when it is zero; expenditure is negative, so
+more spending shows as a negative change.`,
+		Example: `  # Compare January 2025 with December 2024
+  mf-statement compare --period 202501 --csv transactions.csv
+
+  # Compare with January 2024 and write the report as JSON
+  mf-statement compare --period 202501 --compare-to last-year --csv transactions.csv --format json --out compare.json
+
+  # Compare months accumulated with the import command
+  mf-statement compare --period 202501 --store ledger.db`,
+		Args: InputArgs,
+		// A profile may select --db or --store too
+		PreRunE: ApplyConfig(&configOptions, InputArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(csvPaths) == 0 && len(args) == 0 && !dbOptions.Enabled() && storePath == "" {
+				_ = cmd.Help()
+				return domain.NewValidationError("missing required arguments", map[string]interface{}{
+					"period": periodArg,
+					"csv":    csvPaths,
+				})
+			}
+
+			if verbose {
+				logger = util.NewDebugLogger()
+			}
+
+			year, month, _, err := util.ParseYYYYMM(periodArg)
+			if err != nil {
+				return domain.NewValidationError("invalid period format", map[string]interface{}{
+					"period": periodArg,
+					"error":  err.Error(),
+				})
+			}
+			basis, err := usecase.ParseComparisonBasis(compareTo)
+			if err != nil {
+				return err
+			}
+			if format != CompareFormatTable && format != CompareFormatJSON {
+				return domain.NewValidationError("invalid comparison format", map[string]interface{}{
+					"format":  format,
+					"allowed": []string{CompareFormatTable, CompareFormatJSON},
+				})
+			}
+
+			inputs, err := ExpandInputs(append(csvPaths, args...))
+			if err != nil {
+				return err
+			}
+			deduplicator, err := createDeduplicator(dedupeMode, dedupeKey)
+			if err != nil {
+				return err
+			}
+
+			perm, err := ParseFileMode(fileMode)
+			if err != nil {
+				return err
+			}
+			fileOptions := output.FileOptions{Perm: perm, NoClobber: noClobber}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+			defer cancel()
+
+			transactionService, closeSource, err := createTransactionService(ctx, inputs, inputOptions, sourceOptions, s3Config, dbOptions, storePath, cacheOptions)
+			if err != nil {
+				return err
+			}
+			defer closeSource()
+
+			comparison, err := usecase.NewComparisonService(transactionService, deduplicator).Compare(ctx, QueryURI(inputs), year, month, basis)
+			if err != nil {
+				logger.Error("Failed to compare periods", "error", err)
+				return err
+			}
+
+			write := func(w io.Writer) error {
+				if format == CompareFormatJSON {
+					return WriteComparisonJSON(w, comparison)
+				}
+				return WriteComparisonTable(w, comparison)
+			}
+			if outPath == "" || outPath == StdoutPath {
+				return write(cmd.OutOrStdout())
+			}
+
+			WarnIfOverwriting(logger, outPath, fileOptions)
+
+			file, err := output.CreateAtomic(outPath, fileOptions)
+			if err != nil {
+				return domain.NewIOError("failed to create comparison report", err)
+			}
+			defer file.Close()
+			if err := write(file); err != nil {
+				return domain.NewIOError("failed to write comparison report", err)
+			}
+			if err := file.Commit(); err != nil {
+				return domain.NewIOError("failed to write comparison report", err)
+			}
+			logger.Info("Comparison written", "file", outPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&periodArg, "period", "p", "", "Month in YYYYMM format (e.g. 202501)")
+	cmd.Flags().StringVar(&compareTo, "compare-to", string(usecase.CompareToPrevious), "Comparison period: previous (month) or last-year (same month)")
+	cmd.Flags().StringArrayVarP(&csvPaths, "csv", "c", nil, "Path, glob or directory of inputs, repeatable and merged; - for stdin, file:// URI, http(s) URL or s3://bucket/key")
+	cmd.Flags().StringVarP(&outPath, "out", "o", "", "Output file path; - for stdout (default: stdout)")
+	cmd.Flags().StringVarP(&format, "format", "f", CompareFormatTable, "Report format: table or json")
+	cmd.Flags().BoolVar(&noClobber, "no-clobber", false, "Fail instead of overwriting an existing output file")
+	cmd.Flags().StringVar(&fileMode, "file-mode", "0644", "Permissions of the output file in octal")
+	AddInputFlags(cmd.Flags(), &inputOptions)
+	AddSourceFlags(cmd.Flags(), &sourceOptions)
+	AddS3Flags(cmd.Flags(), &s3Config)
+	AddDatabaseFlags(cmd.Flags(), &dbOptions)
+	cmd.Flags().StringVar(&storePath, "store", "", "Read the transactions added with the import command from this SQLite store")
+	AddCacheFlags(cmd.Flags(), &cacheOptions)
+	cmd.Flags().StringVar(&dedupeMode, "dedupe", "off", "Leave out transactions repeated across inputs: off, drop or fail (report drops them too)")
+	cmd.Flags().StringSliceVar(&dedupeKey, "dedupe-key", usecase.DefaultDedupeFields, "Fields identifying a duplicate: date, amount, content, category, id (e.g. OFX FITID); transactions missing one are matched by the default fields")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
+	cmd.Flags().IntVarP(&timeout, "timeout", "t", 30, "Timeout in seconds for processing (default: 30)")
+	AddConfigFlags(cmd.Flags(), &configOptions)
+
+	_ = cmd.MarkFlagRequired("period")
+	cmd.MarkFlagsMutuallyExclusive("csv", "db", "store")
+	cmd.MarkFlagsMutuallyExclusive("cache", "db")
+	cmd.MarkFlagsMutuallyExclusive("cache", "store")
+
+	return cmd
+}
+
+// WriteComparisonJSON writes the comparison as indented JSON
+func WriteComparisonJSON(w io.Writer, comparison domain.Comparison) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(comparison)
+}
+
+// WriteComparisonTable writes the comparison as aligned columns for the terminal
+func WriteComparisonTable(w io.Writer, comparison domain.Comparison) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	row := func(label string, change domain.Change) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%+d\t%s\n", label, change.Current, change.Previous, change.Delta, formatPercent(change.PercentChange))
+	}
+
+	header := func(label string) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\tCHANGE\t%%\n", label, comparison.Period, comparison.ComparedTo)
+	}
+
+	header("")
+	row("Income", comparison.Income)
+	row("Expenditure", comparison.Expenditure)
+	row("Net", comparison.Net)
+	row("Transactions", comparison.TransactionCount)
+	row("Income count", comparison.IncomeCount)
+	row("Expense count", comparison.ExpenseCount)
+	if len(comparison.Categories) > 0 {
+		fmt.Fprintln(tw)
+		header("SPEND BY CATEGORY")
+		for _, category := range comparison.Categories {
+			row(category.Category, category.Change)
+		}
+	}
+	return tw.Flush()
+}
+
+func formatPercent(percent *float64) string {
+	if percent == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.2f%%", *percent)
+}
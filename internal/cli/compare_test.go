@@ -0,0 +1,94 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/cli"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("CompareCommand", func() {
+	var csvPath string
+
+	BeforeEach(func() {
+		csvPath = filepath.Join(GinkgoT().TempDir(), "transactions.csv")
+		Expect(os.WriteFile(csvPath, []byte(`date,amount,content
+2024/01/05,1500,Salary
+2024/12/05,2000,Salary
+2024/12/09,-400,Groceries
+2025/01/05,2000,Salary
+2025/01/09,-600,Groceries
+2025/01/20,-100,Coffee
+`), 0644)).To(Succeed())
+
+		cli.NewRootCommand()
+	})
+
+	It("should print the changes against the previous month as a table", func(ctx SpecContext) {
+		var out bytes.Buffer
+		cmd := cli.NewCompareCommand()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath})
+
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+		Expect(out.String()).To(MatchRegexp(`2025/01\s+2024/12\s+CHANGE`))
+		Expect(out.String()).To(MatchRegexp(`Income\s+2000\s+2000\s+\+0\s+\+0\.00%`))
+		Expect(out.String()).To(MatchRegexp(`Expenditure\s+-700\s+-400\s+-300\s+-75\.00%`))
+		Expect(out.String()).To(MatchRegexp(`Transactions\s+3\s+2\s+\+1\s+\+50\.00%`))
+		Expect(out.String()).NotTo(ContainSubstring("SPEND BY CATEGORY"))
+	}, SpecTimeout(5*time.Second))
+
+	It("should write the comparison with last year as JSON", func(ctx SpecContext) {
+		outPath := filepath.Join(filepath.Dir(csvPath), "compare.json")
+		cmd := cli.NewCompareCommand()
+		cmd.SetArgs([]string{"--period", "202501", "--compare-to", "last-year", "--csv", csvPath, "--format", "json", "--out", outPath})
+
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+		data, err := os.ReadFile(outPath)
+		Expect(err).NotTo(HaveOccurred())
+		var comparison domain.Comparison
+		Expect(json.Unmarshal(data, &comparison)).To(Succeed())
+		Expect(comparison.ComparedTo).To(Equal("2024/01"))
+		Expect(comparison.Income).To(Equal(domain.NewChange(2000, 1500)))
+		Expect(comparison.Expenditure.PercentChange).To(BeNil())
+		Expect(string(data)).To(ContainSubstring(`"percent_change": null`))
+	}, SpecTimeout(5*time.Second))
+
+	It("should apply --file-mode and refuse to overwrite with --no-clobber", func(ctx SpecContext) {
+		outPath := filepath.Join(filepath.Dir(csvPath), "compare.txt")
+		cmd := cli.NewCompareCommand()
+		cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--out", outPath, "--file-mode", "0600", "--no-clobber"})
+
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+		info, err := os.Stat(outPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		cmd = cli.NewCompareCommand()
+		cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--out", outPath, "--no-clobber"})
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("file already exists")))
+	}, SpecTimeout(5*time.Second))
+
+	It("should reject unknown comparison periods", func(ctx SpecContext) {
+		cmd := cli.NewCompareCommand()
+		cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--compare-to", "last-week"})
+
+		Expect(domain.IsValidationError(cmd.ExecuteContext(ctx))).To(BeTrue())
+	}, SpecTimeout(5*time.Second))
+
+	It("should reject input arguments together with --db", func(ctx SpecContext) {
+		cmd := cli.NewCompareCommand()
+		cmd.SetArgs([]string{"--period", "202501", "--db", "ledger.db", csvPath})
+
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("input arguments cannot be combined with --db")))
+	}, SpecTimeout(5*time.Second))
+})
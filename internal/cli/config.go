@@ -0,0 +1,452 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.yaml.in/yaml/v3"
+
+	"mf-statement/internal/domain"
+)
+
+const (
+	// ConfigFileName is looked up in the working directory, then in the user config directory
+	ConfigFileName = "mf-statement.yaml"
+	// EnvPrefix prefixes the environment variables overriding flags, e.g. MF_STATEMENT_CSV_DELIMITER
+	EnvPrefix = "MF_STATEMENT_"
+
+	// mutuallyExclusiveAnnotation is where cobra records the MarkFlagsMutuallyExclusive groups of a flag
+	mutuallyExclusiveAnnotation = "cobra_annotation_mutually_exclusive"
+)
+
+// Config is the project configuration file. Each profile bundles flag values by
+// flag name, e.g. "csv-delimiter: ;", with lists for repeatable flags. Profiles hold
+// flag values only; categories come from the inputs.
+type Config struct {
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+// Profile maps flag names to their values. Values are kept as YAML nodes so flags get
+// the text of the file, e.g. file-mode 0644 rather than the integer it would decode to.
+// Top-level flags apply to every command defining them; a key naming a command, such as
+// compare, holds a section of flags for that command only, which win over the others.
+type Profile map[string]yaml.Node
+
+// ConfigOptions are the flags selecting the config file and profile
+type ConfigOptions struct {
+	Path    string
+	Profile string
+}
+
+// AddConfigFlags registers --config and --profile
+func AddConfigFlags(flags *pflag.FlagSet, options *ConfigOptions) {
+	flags.StringVar(&options.Path, "config", "", "Config file (default: ./"+ConfigFileName+", then $XDG_CONFIG_HOME/"+ConfigFileName+"; env "+EnvPrefix+"CONFIG)")
+	flags.StringVar(&options.Profile, "profile", "", "Profile of the config file supplying flag values (default: default_profile of the config file; env "+EnvPrefix+"PROFILE)")
+}
+
+// ConfigSetting is the effective value of one flag and where it came from
+type ConfigSetting struct {
+	Flag   string
+	Values []string
+	Source string
+}
+
+// EffectiveConfig is the merged configuration of a command: command-line flags win
+// over environment variables, which win over the profile
+type EffectiveConfig struct {
+	Path     string
+	Profile  string
+	Settings []ConfigSetting
+}
+
+// LoadConfig reads a config file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, domain.NewIOError("failed to read config file", err)
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, domain.NewParseError(fmt.Sprintf("failed to parse config file %s", path), err)
+	}
+	return &config, nil
+}
+
+// FindConfigFile returns the config file of the working directory or of the user
+// config directory, or "" when there is none
+func FindConfigFile() (string, error) {
+	var candidates []string
+	if wd, err := os.Getwd(); err == nil {
+		candidates = append(candidates, filepath.Join(wd, ConfigFileName))
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		candidates = append(candidates, filepath.Join(dir, ConfigFileName))
+	}
+
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", domain.NewIOError("failed to look up config file", err)
+		}
+	}
+	return "", nil
+}
+
+// Resolve merges the profile and environment variables for the flags of cmd that were
+// not given on the command line
+func (o ConfigOptions) Resolve(cmd *cobra.Command) (*EffectiveConfig, error) {
+	flags := cmd.Flags()
+	effective := &EffectiveConfig{Path: firstNonEmpty(o.Path, os.Getenv(EnvPrefix+"CONFIG"))}
+	profileName := firstNonEmpty(o.Profile, os.Getenv(EnvPrefix+"PROFILE"))
+
+	if effective.Path == "" {
+		path, err := FindConfigFile()
+		if err != nil {
+			return nil, err
+		}
+		effective.Path = path
+	}
+
+	var settings map[string]ConfigSetting
+	if effective.Path != "" {
+		config, err := LoadConfig(effective.Path)
+		if err != nil {
+			return nil, err
+		}
+		effective.Profile = firstNonEmpty(profileName, config.DefaultProfile)
+		if effective.Profile != "" {
+			profile, ok := config.Profiles[effective.Profile]
+			if !ok {
+				return nil, domain.NewValidationError("unknown profile", map[string]interface{}{
+					"profile":  effective.Profile,
+					"config":   effective.Path,
+					"profiles": config.profileNames(),
+				})
+			}
+			if settings, err = profileSettings(cmd, profile); err != nil {
+				return nil, fmt.Errorf("profile %s: %w", effective.Profile, err)
+			}
+			for name, setting := range settings {
+				setting.Source = "profile " + effective.Profile
+				settings[name] = setting
+			}
+		}
+	} else if profileName != "" {
+		return nil, domain.NewValidationError("profile given but no config file found", map[string]interface{}{
+			"profile": profileName,
+			"config":  ConfigFileName,
+		})
+	}
+	if settings == nil {
+		settings = map[string]ConfigSetting{}
+	}
+
+	fromEnv := map[string]bool{}
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if value, ok := os.LookupEnv(EnvName(flag.Name)); ok && !isConfigFlag(flag.Name) {
+			settings[flag.Name] = ConfigSetting{Flag: flag.Name, Values: []string{value}, Source: EnvName(flag.Name)}
+			fromEnv[flag.Name] = true
+		}
+	})
+
+	// precedence ranks where a flag's value comes from: the command line, then the
+	// environment, then the profile
+	precedence := func(name string) int {
+		switch {
+		case flags.Lookup(name).Changed:
+			return 3
+		case fromEnv[name]:
+			return 2
+		case settings[name].Flag != "":
+			return 1
+		}
+		return 0
+	}
+
+	for name, setting := range settings {
+		// Flags on the command line win, and so does any flag from a higher source over
+		// the flags it is mutually exclusive with, e.g. --store over the csv of a profile
+		overridden := false
+		for _, peer := range exclusivePeers(flags, name) {
+			overridden = overridden || precedence(peer) > precedence(name)
+		}
+		if flags.Lookup(name).Changed || overridden {
+			continue
+		}
+		effective.Settings = append(effective.Settings, setting)
+	}
+	sort.Slice(effective.Settings, func(i, j int) bool {
+		return effective.Settings[i].Flag < effective.Settings[j].Flag
+	})
+	return effective, nil
+}
+
+// Apply sets the flags to their configured values
+func (c *EffectiveConfig) Apply(flags *pflag.FlagSet) error {
+	for _, setting := range c.Settings {
+		for _, value := range setting.Values {
+			if err := flags.Set(setting.Flag, value); err != nil {
+				return domain.NewValidationError(fmt.Sprintf("invalid value of %s from %s", setting.Flag, setting.Source), map[string]interface{}{
+					"value": value,
+					"error": err.Error(),
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// EnvName returns the environment variable overriding a flag
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// profileSettings returns the profile values of the flags of cmd: the top-level ones,
+// overridden by those of the section named after cmd
+func profileSettings(cmd *cobra.Command, profile Profile) (map[string]ConfigSetting, error) {
+	commands := configurableCommands()
+	settings := map[string]ConfigSetting{}
+	var section Profile
+	for name, value := range profile {
+		if _, ok := commands[name]; ok {
+			if value.Kind != yaml.MappingNode {
+				return nil, domain.NewValidationError(fmt.Sprintf("section %q must map flag names to values", name), map[string]interface{}{"section": name})
+			}
+			if name == cmd.Name() {
+				if err := value.Decode(&section); err != nil {
+					return nil, domain.NewParseError(fmt.Sprintf("failed to read section %q", name), err)
+				}
+			}
+			continue
+		}
+		if cmd.Flags().Lookup(name) == nil && definesFlag(commands, name) {
+			// Flags of other commands, such as the template of generate, apply to those only
+			continue
+		}
+		setting, err := profileSetting(cmd.Flags(), name, value)
+		if err != nil {
+			return nil, err
+		}
+		settings[name] = setting
+	}
+
+	for name, value := range section {
+		setting, err := profileSetting(cmd.Flags(), name, value)
+		if err != nil {
+			return nil, fmt.Errorf("section %s: %w", cmd.Name(), err)
+		}
+		settings[name] = setting
+	}
+	return settings, nil
+}
+
+// configurableCommands creates the commands that read the config file, by name
+func configurableCommands() map[string]func() *cobra.Command {
+	return map[string]func() *cobra.Command{
+		"generate":   NewGenerateCommand,
+		"compare":    NewCompareCommand,
+		"import":     NewImportCommand,
+		"serve":      NewServeCommand,
+		"serve-grpc": NewServeGRPCCommand,
+	}
+}
+
+// definesFlag reports whether any of the commands has the flag
+func definesFlag(commands map[string]func() *cobra.Command, name string) bool {
+	for _, newCommand := range commands {
+		if newCommand().Flags().Lookup(name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyConfig returns a PreRunE setting the flags of the command to their profile and
+// environment values before cobra checks the required ones. args, when given, then
+// validates the positional arguments, as a profile may set the flags they depend on.
+func ApplyConfig(options *ConfigOptions, args cobra.PositionalArgs) func(cmd *cobra.Command, positional []string) error {
+	return func(cmd *cobra.Command, positional []string) error {
+		effective, err := options.Resolve(cmd)
+		if err != nil {
+			return err
+		}
+		if err := effective.Apply(cmd.Flags()); err != nil {
+			return err
+		}
+		if args == nil {
+			return nil
+		}
+		return args(cmd, positional)
+	}
+}
+
+func profileSetting(flags *pflag.FlagSet, name string, value yaml.Node) (ConfigSetting, error) {
+	flag := flags.Lookup(name)
+	if flag == nil || isConfigFlag(name) {
+		return ConfigSetting{}, domain.NewValidationError(fmt.Sprintf("unknown flag %q in profile", name), map[string]interface{}{"flag": name})
+	}
+
+	setting := ConfigSetting{Flag: name}
+	switch value.Kind {
+	case yaml.SequenceNode:
+		if _, repeatable := flag.Value.(pflag.SliceValue); !repeatable {
+			return ConfigSetting{}, domain.NewValidationError(fmt.Sprintf("flag %q takes a single value", name), map[string]interface{}{"flag": name})
+		}
+		for _, item := range value.Content {
+			scalar, err := scalarString(name, item)
+			if err != nil {
+				return ConfigSetting{}, err
+			}
+			setting.Values = append(setting.Values, scalar)
+		}
+	default:
+		scalar, err := scalarString(name, &value)
+		if err != nil {
+			return ConfigSetting{}, err
+		}
+		setting.Values = []string{scalar}
+	}
+	return setting, nil
+}
+
+func scalarString(name string, value *yaml.Node) (string, error) {
+	if value.Kind != yaml.ScalarNode || value.Tag == "!!null" {
+		return "", domain.NewValidationError("profile values must be scalars or lists of scalars", map[string]interface{}{"flag": name})
+	}
+	return value.Value, nil
+}
+
+// exclusivePeers returns the flags sharing a mutually exclusive group with name
+func exclusivePeers(flags *pflag.FlagSet, name string) []string {
+	var peers []string
+	for _, group := range flags.Lookup(name).Annotations[mutuallyExclusiveAnnotation] {
+		for _, peer := range strings.Fields(group) {
+			if peer != name && flags.Lookup(peer) != nil {
+				peers = append(peers, peer)
+			}
+		}
+	}
+	return peers
+}
+
+func isConfigFlag(name string) bool {
+	return name == "config" || name == "profile"
+}
+
+func (c *Config) profileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration file",
+	}
+	cmd.AddCommand(newConfigShowCommand())
+	return cmd
+}
+
+func newConfigShowCommand() *cobra.Command {
+	var options ConfigOptions
+
+	commands := configurableCommands()
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cmd := &cobra.Command{
+		Use:   "show [command]",
+		Short: "Print the effective settings of a command",
+		Long: `Print the flag values of a command supplied by the config file profile and ` + EnvPrefix + `*
+environment variables after merging, each commented with where it came from. The command is
+one of ` + strings.Join(names, ", ") + ` (default: generate).`,
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: names,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := "generate"
+			if len(args) == 1 {
+				name = args[0]
+			}
+			target := commands[name]()
+			effective, err := options.Resolve(target)
+			if err != nil {
+				return err
+			}
+			return effective.Write(cmd.OutOrStdout(), target.Flags())
+		},
+	}
+	AddConfigFlags(cmd.Flags(), &options)
+	return cmd
+}
+
+// Write prints the settings as a YAML profile, commented with their sources
+func (c *EffectiveConfig) Write(w io.Writer, flags *pflag.FlagSet) error {
+	fmt.Fprintf(w, "# config: %s\n", firstNonEmpty(c.Path, "none"))
+	fmt.Fprintf(w, "# profile: %s\n", firstNonEmpty(c.Profile, "none"))
+	if len(c.Settings) == 0 {
+		return nil
+	}
+
+	document := &yaml.Node{Kind: yaml.MappingNode}
+	for _, setting := range c.Settings {
+		values := setting.Values
+		if setting.Flag == "header" {
+			values = redactHeaders(values)
+		}
+
+		var value *yaml.Node
+		if _, repeatable := flags.Lookup(setting.Flag).Value.(pflag.SliceValue); repeatable {
+			value = &yaml.Node{Kind: yaml.SequenceNode}
+			for _, v := range values {
+				value.Content = append(value.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: v})
+			}
+		} else {
+			value = &yaml.Node{Kind: yaml.ScalarNode, Value: values[len(values)-1]}
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: setting.Flag, LineComment: setting.Source}
+		document.Content = append(document.Content, key, value)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// redactHeaders hides header values, which usually carry credentials
+func redactHeaders(headers []string) []string {
+	redacted := make([]string, len(headers))
+	for i, header := range headers {
+		name, _, _ := strings.Cut(header, ":")
+		redacted[i] = name + ": ***"
+	}
+	return redacted
+}
@@ -0,0 +1,177 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/cli"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("Config", func() {
+	var (
+		tempDir    string
+		configPath string
+		outPath    string
+	)
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		csvPath := filepath.Join(tempDir, "checking.csv")
+		Expect(os.WriteFile(csvPath, []byte("Export\ndate;amount;content\n2025/01/05;-200;Groceries\n"), 0644)).To(Succeed())
+
+		outPath = filepath.Join(tempDir, "statement.json")
+		configPath = filepath.Join(tempDir, cli.ConfigFileName)
+		Expect(os.WriteFile(configPath, []byte(`
+default_profile: checking
+profiles:
+  checking:
+    csv:
+      - `+csvPath+`
+    csv-delimiter: ";"
+    csv-skip-lines: 1
+    out: `+outPath+`
+    header:
+      - "Authorization: Bearer secret"
+  savings:
+    csv: savings.csv
+`), 0644)).To(Succeed())
+
+		cli.NewRootCommand()
+	})
+
+	It("should run generate with the flags of the profile", func(ctx SpecContext) {
+		cmd := cli.NewGenerateCommand()
+		cmd.SetArgs([]string{"--period", "202501", "--config", configPath, "--profile", "checking"})
+
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+		data, err := os.ReadFile(outPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"total_expenditure": -200`))
+	}, SpecTimeout(5*time.Second))
+
+	It("should let environment variables override the profile and flags override both", func(ctx SpecContext) {
+		GinkgoT().Setenv(cli.EnvPrefix+"CONFIG", configPath)
+		GinkgoT().Setenv(cli.EnvName("out"), filepath.Join(tempDir, "from-env.json"))
+		GinkgoT().Setenv(cli.EnvName("csv-skip-lines"), "0")
+
+		cmd := cli.NewGenerateCommand()
+		cmd.SetArgs([]string{"--period", "202501", "--csv-skip-lines", "1"})
+
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+		Expect(filepath.Join(tempDir, "from-env.json")).To(BeAnExistingFile())
+		Expect(outPath).NotTo(BeAnExistingFile())
+	}, SpecTimeout(5*time.Second))
+
+	It("should pass profile values to the flags as written", func() {
+		Expect(os.WriteFile(configPath, []byte("profiles:\n  private:\n    file-mode: 0644\n    csv-delimiter: \";\"\n"), 0644)).To(Succeed())
+
+		effective, err := cli.ConfigOptions{Path: configPath, Profile: "private"}.Resolve(cli.NewGenerateCommand())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(effective.Settings).To(ContainElement(cli.ConfigSetting{Flag: "file-mode", Values: []string{"0644"}, Source: "profile private"}))
+	})
+
+	It("should leave out profile values conflicting with flags of the command line", func(ctx SpecContext) {
+		Expect(os.WriteFile(configPath, []byte("profiles:\n  monthly:\n    csv: checking.csv\n    period: \"202501\"\n    csv-delimiter: \";\"\n"), 0644)).To(Succeed())
+
+		cmd := cli.NewGenerateCommand()
+		Expect(cmd.ParseFlags([]string{"--store", "ledger.db", "--all-periods", "--out-dir", tempDir})).To(Succeed())
+		effective, err := cli.ConfigOptions{Path: configPath, Profile: "monthly"}.Resolve(cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(effective.Settings).To(ConsistOf(cli.ConfigSetting{Flag: "csv-delimiter", Values: []string{";"}, Source: "profile monthly"}))
+
+		// The store is looked up instead of cobra rejecting --store next to the profile's csv
+		cmd = cli.NewGenerateCommand()
+		cmd.SetArgs([]string{"--config", configPath, "--profile", "monthly", "--store", filepath.Join(tempDir, "missing.db"), "--all-periods", "--out-dir", tempDir})
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("transaction store not found")))
+	}, SpecTimeout(5*time.Second))
+
+	It("should reject unknown profiles and flags", func() {
+		_, err := cli.ConfigOptions{Path: configPath, Profile: "credit"}.Resolve(cli.NewGenerateCommand())
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+
+		Expect(os.WriteFile(configPath, []byte("profiles:\n  typo:\n    csv-delimter: \";\"\n"), 0644)).To(Succeed())
+		_, err = cli.ConfigOptions{Path: configPath, Profile: "typo"}.Resolve(cli.NewGenerateCommand())
+		Expect(err).To(MatchError(ContainSubstring("csv-delimter")))
+	})
+
+	Context("with command sections", func() {
+		var reportPath string
+
+		BeforeEach(func() {
+			reportPath = filepath.Join(tempDir, "report.json")
+			Expect(os.WriteFile(configPath, []byte(`
+profiles:
+  checking:
+    csv:
+      - `+filepath.Join(tempDir, "checking.csv")+`
+    csv-delimiter: ";"
+    csv-skip-lines: 1
+    template: statement.tmpl
+    compare:
+      format: json
+      out: `+reportPath+`
+`), 0644)).To(Succeed())
+		})
+
+		It("should apply the profile to compare, skipping the flags of other commands", func(ctx SpecContext) {
+			cmd := cli.NewCompareCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--config", configPath, "--profile", "checking"})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(reportPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"current"`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should show the settings of the named command", func() {
+			var out bytes.Buffer
+			cmd := cli.NewConfigCommand()
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{"show", "compare", "--config", configPath, "--profile", "checking"})
+
+			Expect(cmd.Execute()).To(Succeed())
+
+			Expect(out.String()).To(ContainSubstring("format: json # profile checking"))
+			Expect(out.String()).To(ContainSubstring(`csv-delimiter: ; # profile checking`))
+			Expect(out.String()).NotTo(ContainSubstring("template"))
+		})
+
+		It("should reject flags a section's command does not have", func() {
+			Expect(os.WriteFile(configPath, []byte("profiles:\n  typo:\n    import:\n      period: \"202501\"\n"), 0644)).To(Succeed())
+
+			_, err := cli.ConfigOptions{Path: configPath, Profile: "typo"}.Resolve(cli.NewImportCommand())
+			Expect(err).To(MatchError(ContainSubstring(`unknown flag "period"`)))
+		})
+	})
+
+	It("should find the config file in the user config directory", func() {
+		GinkgoT().Setenv("XDG_CONFIG_HOME", tempDir)
+
+		Expect(cli.FindConfigFile()).To(Equal(configPath))
+	})
+
+	It("should show the effective settings with their sources", func() {
+		GinkgoT().Setenv(cli.EnvName("dedupe"), "drop")
+
+		var out bytes.Buffer
+		cmd := cli.NewConfigCommand()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"show", "--config", configPath})
+
+		Expect(cmd.Execute()).To(Succeed())
+
+		Expect(out.String()).To(ContainSubstring("# profile: checking"))
+		Expect(out.String()).To(ContainSubstring(`csv-delimiter: ; # profile checking`))
+		Expect(out.String()).To(ContainSubstring("dedupe: drop # " + cli.EnvName("dedupe")))
+		Expect(out.String()).To(ContainSubstring("Authorization: ***"))
+		Expect(out.String()).NotTo(ContainSubstring("secret"))
+	})
+})
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/spf13/pflag"
+	_ "modernc.org/sqlite"
+
+	"mf-statement/internal/adapters/in/database"
+	"mf-statement/internal/domain"
+)
+
+// DatabaseOptions collects the flags reading transactions from a SQL database instead of a CSV
+type DatabaseOptions struct {
+	DSN        string
+	Driver     string
+	Query      string
+	Columns    string
+	DateFormat string
+}
+
+// AddDatabaseFlags registers the flags configuring the SQL transaction source
+func AddDatabaseFlags(flags *pflag.FlagSet, options *DatabaseOptions) {
+	flags.StringVar(&options.DSN, "db", "", "Read transactions from a database: SQLite file or postgres:// URL")
+	flags.StringVar(&options.Driver, "db-driver", "", "database/sql driver: sqlite or pgx (default: from --db)")
+	flags.StringVar(&options.Query, "db-query", database.DefaultQuery, "Query selecting the transactions")
+	flags.StringVar(&options.Columns, "db-columns", "", "Column mapping as field=column pairs, e.g. date=posted_at,amount=cents,content=memo,category=tag")
+	flags.StringVar(&options.DateFormat, "db-date-format", "", "Go layout of dates stored as text, year first so it sorts like the dates (default: 2006-01-02 for SQLite)")
+}
+
+// Enabled reports whether --db was given
+func (o DatabaseOptions) Enabled() bool {
+	return o.DSN != ""
+}
+
+// DriverName returns the configured driver, inferring it from the DSN when unset
+func (o DatabaseOptions) DriverName() string {
+	if o.Driver != "" {
+		return o.Driver
+	}
+	if strings.HasPrefix(o.DSN, "postgres://") || strings.HasPrefix(o.DSN, "postgresql://") {
+		return "pgx"
+	}
+	return "sqlite"
+}
+
+// ParseColumns parses field=column pairs on top of the default mapping
+func ParseColumns(mapping string) (database.Columns, error) {
+	columns := database.DefaultColumns()
+	if mapping == "" {
+		return columns, nil
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		field, column, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return database.Columns{}, domain.NewValidationError("column mapping must be field=column", map[string]interface{}{
+				"mapping": pair,
+			})
+		}
+
+		switch strings.TrimSpace(field) {
+		case "date":
+			columns.Date = strings.TrimSpace(column)
+		case "amount":
+			columns.Amount = strings.TrimSpace(column)
+		case "content":
+			columns.Content = strings.TrimSpace(column)
+		case "category":
+			columns.Category = strings.TrimSpace(column)
+		default:
+			return database.Columns{}, domain.NewValidationError("unknown transaction field in column mapping", map[string]interface{}{
+				"field": field,
+			})
+		}
+	}
+	return columns, nil
+}
+
+// OpenTransactionService connects to the database; the caller closes the returned DB
+func (o DatabaseOptions) OpenTransactionService() (*database.TransactionService, error) {
+	columns, err := ParseColumns(o.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	driver := o.DriverName()
+	dateFormat := o.DateFormat
+	if dateFormat == "" && driver == "sqlite" {
+		dateFormat = "2006-01-02"
+	}
+	if err := database.ValidateDateFormat(dateFormat); err != nil {
+		return nil, err
+	}
+	if driver == "sqlite" {
+		// Opening creates missing SQLite files, so a mistyped --db would read an empty database
+		if path := sqlitePath(o.DSN); path != "" {
+			if _, err := os.Stat(path); err != nil {
+				return nil, domain.NewValidationError(fmt.Sprintf("database %s not found", path), map[string]interface{}{
+					"db": o.DSN,
+				})
+			}
+		}
+	}
+
+	db, err := sql.Open(driver, o.DSN)
+	if err != nil {
+		return nil, domain.NewValidationError("invalid database configuration", map[string]interface{}{
+			"driver": driver,
+			"error":  err.Error(),
+		})
+	}
+
+	service, err := database.NewTransactionService(db, database.Config{
+		Query:        o.Query,
+		Columns:      columns,
+		Placeholders: database.PlaceholderForDriver(driver),
+		DateFormat:   dateFormat,
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return service, nil
+}
+
+// sqlitePath returns the file named by a SQLite DSN, or "" for in-memory databases
+func sqlitePath(dsn string) string {
+	path, _, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?")
+	if path == ":memory:" {
+		return ""
+	}
+	return path
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"mf-statement/internal/adapters/in"
+)
+
+func NewFormatsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "formats",
+		Short: "List supported input formats",
+		Long: `List the input formats accepted by --input-format. With --input-format auto, each input's
+format is detected from its content, then from its file extension, and anything else is read
+as the default format.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			registry := in.NewDefaultParserRegistry()
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "FORMAT\tALIASES\tEXTENSIONS\tDETECTED BY\tDESCRIPTION")
+			for _, format := range registry.Formats() {
+				var detection []string
+				if format.Sniff != nil {
+					detection = append(detection, "content")
+				}
+				if len(format.Extensions) > 0 {
+					detection = append(detection, "extension")
+				}
+				if format.Name == registry.Fallback() {
+					detection = append(detection, "default")
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", format.Name, orDash(format.Aliases), orDash(format.Extensions),
+					strings.Join(detection, ", "), format.Description)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func orDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, " ")
+}
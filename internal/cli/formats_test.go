@@ -0,0 +1,33 @@
+package cli_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/cli"
+)
+
+var _ = Describe("FormatsCommand", func() {
+	It("should list every input format with how it is detected", func() {
+		var out bytes.Buffer
+		cmd := cli.NewFormatsCommand()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{})
+
+		Expect(cmd.Execute()).To(Succeed())
+
+		Expect(out.String()).To(ContainSubstring("FORMAT"))
+		Expect(out.String()).To(MatchRegexp(`ofx\s+qfx\s+\.ofx \.qfx\s+content, extension\s+OFX/QFX bank statements`))
+		Expect(out.String()).To(MatchRegexp(`csv\s+-\s+\.csv \.txt\s+extension, default`))
+		Expect(out.String()).To(ContainSubstring("tsv"))
+	})
+
+	It("should reject arguments", func() {
+		cmd := cli.NewFormatsCommand()
+		cmd.SetArgs([]string{"csv"})
+
+		Expect(cmd.Execute()).To(HaveOccurred())
+	})
+})
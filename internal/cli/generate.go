@@ -2,9 +2,10 @@ package cli
 
 import (
 	"context"
-	"mf-statement/internal/adapters/in"
+	"fmt"
+	"mf-statement/internal/adapters/ledger"
+	"mf-statement/internal/adapters/objectstore"
 	"mf-statement/internal/adapters/out/output"
-	"mf-statement/internal/adapters/out/parser"
 	"mf-statement/internal/domain"
 	"mf-statement/internal/usecase"
 	"mf-statement/internal/util"
@@ -16,11 +17,28 @@ import (
 
 func NewGenerateCommand() *cobra.Command {
 	var (
-		periodArg      string
-		csvPath        string
-		outputFilePath string
-		verbose        bool
-		timeout        int
+		periodArg       string
+		csvPaths        []string
+		outputPaths     []string
+		templatePath    string
+		formats         []string
+		noClobber       bool
+		fileMode        string
+		allPeriods      bool
+		outDir          string
+		filenamePattern string
+		sourceOptions   SourceOptions
+		dbOptions       DatabaseOptions
+		storePath       string
+		cacheOptions    CacheOptions
+		inputOptions    InputOptions
+		dedupeMode      string
+		dedupeKey       []string
+		s3Config        objectstore.Config
+		configOptions   ConfigOptions
+		watchOptions    WatchOptions
+		verbose         bool
+		timeout         int
 	)
 
 	cmd := &cobra.Command{
@@ -43,73 +61,346 @@ Where:
   # Generate with custom output file
   mf-statement generate --period 202501 --csv transactions.csv --out statement.json
   
+  # Stream newline-delimited JSON for jq or log pipelines
+  mf-statement generate --period 202501 --csv transactions.csv --format ndjson
+  
+  # Render the statement through a custom Go template
+  mf-statement generate --period 202501 --csv transactions.csv --template statement.tmpl --out statement.txt
+  
+  # Write several outputs from a single parse
+  mf-statement generate --period 202501 --csv transactions.csv --out statement.json --out statement.csv --out -
+  
+  # Write one statement per month into a directory (statements/2025-01.json, ...)
+  mf-statement generate --all-periods --csv transactions.csv --out-dir statements/
+  
+  # Read a compressed export from stdin
+  zcat transactions.csv.gz | mf-statement generate --period 202501 --csv -
+  
+  # Merge per-account exports into one statement
+  mf-statement generate --period 202501 --csv "exports/2025-*.csv" --csv savings.csv
+  
+  # Merge overlapping exports, listing transactions found in both separately
+  mf-statement generate --period 202501 --csv checking-old.csv --csv checking-new.csv --dedupe report
+  
+  # Read an OFX/QFX bank statement; the format is detected from the content
+  mf-statement generate --period 202501 --csv checking.ofx
+  
+  # Read a JSON API dump with decimal amounts nested in a response envelope
+  mf-statement generate --period 202501 --csv dump.json --json-root data.items --json-fields amount=amount.value,content=description --json-amount-unit major
+  
+  # Read a Quicken export with day-first dates
+  mf-statement generate --period 202501 --csv legacy.qif --qif-date-format dmy
+  
+  # Download the CSV over HTTPS with an auth header
+  mf-statement generate --period 202501 --csv https://example.com/transactions.csv --header "Authorization: Bearer $TOKEN"
+  
+  # Read from and write to S3-compatible object storage
+  mf-statement generate --period 202501 --csv s3://exports/transactions.csv --out s3://statements/2025-01.json
+  
+  # Read transactions from a SQLite database, filtering the period in SQL
+  mf-statement generate --period 202501 --db ledger.db --db-query "SELECT posted_on, cents, memo FROM entries" --db-columns date=posted_on,amount=cents,content=memo
+  
+  # Generate from the transactions accumulated with the import command
+  mf-statement generate --period 202501 --store ledger.db
+  
+  # Index a large export on the first run so statements of other months skip parsing
+  mf-statement generate --period 202501 --csv transactions-2024-2025.csv --cache
+  
+  # Use the source, column mapping and outputs of the "checking" profile in mf-statement.yaml
+  mf-statement generate --period 202501 --profile checking
+  
+  # Regenerate the dashboard JSON whenever an export job drops a file into exports/
+  mf-statement generate --period 202501 --csv exports/ --out dashboard/statement.json --watch
+  
+  # Refuse to replace a statement that already exists
+  mf-statement generate --period 202501 --csv transactions.csv --out statement.json --no-clobber
+  
   # Generate with verbose logging
   mf-statement generate --period 202501 --csv transactions.csv --verbose
   
   # Generate with custom timeout
   mf-statement generate --period 202501 --csv transactions.csv --timeout 60`,
+		Args: InputArgs,
+		// A profile may select --db or --store too
+		PreRunE: ApplyConfig(&configOptions, InputArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if periodArg == "" || csvPath == "" {
+			if (periodArg == "" && !allPeriods) || (len(csvPaths) == 0 && !dbOptions.Enabled() && storePath == "") {
 				_ = cmd.Help()
 				return domain.NewValidationError("missing required arguments", map[string]interface{}{
 					"period": periodArg,
-					"csv":    csvPath,
+					"csv":    csvPaths,
 				})
 			}
 
-			year, month, display, err := util.ParseYYYYMM(periodArg)
-			if err != nil {
-				return domain.NewValidationError("invalid period format", map[string]interface{}{
-					"period": periodArg,
-					"error":  err.Error(),
-				})
-			}
+			generate := func(ctx context.Context) error {
+				// Unquoted globs are expanded by the shell into --csv a.csv b.csv c.csv
+				inputs, err := ExpandInputs(append(csvPaths, args...))
+				if err != nil {
+					return err
+				}
+				uri := QueryURI(inputs)
 
-			if verbose {
-				logger = util.NewDebugLogger()
-			}
+				if verbose {
+					logger = util.NewDebugLogger()
+				}
 
-			logger.Info("Generating statement for period", "period", display)
-			logger.Debug("CSV path", "path", csvPath)
-			logger.Debug("Output file", "file", outputFilePath)
-
-			var writer output.Writer
-			if outputFilePath != "" {
-				writer = output.NewJSONFile(outputFilePath)
-				logger.Info("Output will be written to file", "file", outputFilePath)
-			} else {
-				writer = output.NewJSON(os.Stdout)
-				logger.Info("Output will be written to stdout")
-			}
+				perm, err := ParseFileMode(fileMode)
+				if err != nil {
+					return err
+				}
+				outputOptions := OutputOptions{
+					Outputs:      outputPaths,
+					Formats:      formats,
+					TemplatePath: templatePath,
+					File:         output.FileOptions{Perm: perm, NoClobber: noClobber},
+					S3:           s3Config,
+				}
+
+				deduplicator, err := createDeduplicator(dedupeMode, dedupeKey)
+				if err != nil {
+					return err
+				}
+
+				targets, err := outputOptions.Targets()
+				if err != nil {
+					return err
+				}
 
-			csvSource := in.NewCSVFileSource()
-			csvParser := parser.NewCSV()
+				// The timeout also covers opening --db or --store and the ping that follows
+				ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+				defer cancel()
 
-			transactionService := usecase.NewTransactionService(csvSource, csvParser)
+				if hasFormat(targets, FormatNDJSON) {
+					if err := checkNDJSONStreaming(targets, allPeriods, dbOptions.Enabled() || storePath != "", deduplicator != nil, cacheOptions.Enabled); err != nil {
+						return err
+					}
+					year, month, display, err := util.ParseYYYYMM(periodArg)
+					if err != nil {
+						return domain.NewValidationError("invalid period format", map[string]interface{}{
+							"period": periodArg,
+							"error":  err.Error(),
+						})
+					}
+					logger.Info("Streaming statement for period", "period", display)
+					outputOptions.LogTargets(logger)
+					if err := streamNDJSON(ctx, inputs, inputOptions, sourceOptions, s3Config, targets[0].Path, outputOptions.File, display, year, month); err != nil {
+						logger.Error("Failed to generate statement", "error", err)
+						return err
+					}
+					logger.Info("Statement generated successfully")
+					return nil
+				}
 
-			statementService := usecase.NewStatementService(transactionService, writer)
+				transactionService, closeSource, err := createTransactionService(ctx, inputs, inputOptions, sourceOptions, s3Config, dbOptions, storePath, cacheOptions)
+				if err != nil {
+					return err
+				}
+				defer closeSource()
 
-			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-			defer cancel()
+				if allPeriods {
+					return generateAllPeriods(ctx, transactionService, deduplicator, uri, outDir, filenamePattern, outputOptions)
+				}
 
-			if err := statementService.GenerateMonthlyStatement(ctx, csvPath, display, year, month); err != nil {
-				logger.Error("Failed to generate statement", "error", err)
-				return err
+				year, month, display, err := util.ParseYYYYMM(periodArg)
+				if err != nil {
+					return domain.NewValidationError("invalid period format", map[string]interface{}{
+						"period": periodArg,
+						"error":  err.Error(),
+					})
+				}
+
+				logger.Info("Generating statement for period", "period", display)
+				logger.Debug("Output files", "files", outputPaths)
+
+				writer, err := outputOptions.CreateWriter()
+				if err != nil {
+					return err
+				}
+				outputOptions.LogTargets(logger)
+
+				statementService := usecase.NewDedupingStatementService(transactionService, writer, deduplicator)
+
+				if err := statementService.GenerateMonthlyStatement(ctx, uri, display, year, month); err != nil {
+					logger.Error("Failed to generate statement", "error", err)
+					return err
+				}
+
+				logger.Info("Statement generated successfully")
+				return nil
 			}
 
-			logger.Info("Statement generated successfully")
-			return nil
+			if !watchOptions.Enabled {
+				return generate(context.Background())
+			}
+			watchedOutDir := ""
+			if allPeriods {
+				watchedOutDir = outDir
+			}
+			return watchOptions.Run(cmd.Context(), append(csvPaths, args...), outputPaths, watchedOutDir, generate)
 		},
 	}
 
 	cmd.Flags().StringVarP(&periodArg, "period", "p", "", "Month in YYYYMM format (e.g. 202501)")
-	cmd.Flags().StringVarP(&csvPath, "csv", "c", "", "Path to CSV file or file:// URI")
-	cmd.Flags().StringVarP(&outputFilePath, "out", "o", "", "Output JSON file path (default: stdout)")
+	cmd.Flags().StringArrayVarP(&csvPaths, "csv", "c", nil, "Path, glob or directory of CSV files, repeatable and merged into one statement (gzip, bzip2, zstd or zip compressed allowed), - for stdin, file:// URI, http(s) URL or s3://bucket/key")
+	cmd.Flags().StringArrayVarP(&outputPaths, "out", "o", nil, "Output file path or s3://bucket/key, repeatable; - for stdout (default: stdout)")
+	cmd.Flags().StringSliceVarP(&formats, "format", "f", nil, "Output formats: json, ndjson, csv or template; one for all outputs or one per --out (default: template with --template, else from extension). ndjson streams one --period to a single output in input order and cannot be combined with other outputs, --all-periods, --db, --store, --dedupe or --cache")
+	cmd.Flags().StringVar(&templatePath, "template", "", "Render the statement with a Go text/template file instead of JSON")
+	cmd.Flags().BoolVar(&noClobber, "no-clobber", false, "Fail instead of overwriting an existing output file")
+	cmd.Flags().StringVar(&fileMode, "file-mode", "0644", "Permissions of the output file in octal")
+	cmd.Flags().BoolVar(&allPeriods, "all-periods", false, "Write one statement per month found in the CSV into --out-dir")
+	cmd.Flags().StringVar(&outDir, "out-dir", "", "Directory or s3:// prefix receiving the statements of --all-periods")
+	cmd.Flags().StringVar(&filenamePattern, "filename-pattern", DefaultFilenamePattern, "File name of each --all-periods statement; {yyyy}, {mm} and {period} are replaced")
+	AddInputFlags(cmd.Flags(), &inputOptions)
+	AddSourceFlags(cmd.Flags(), &sourceOptions)
+	AddS3Flags(cmd.Flags(), &s3Config)
+	AddDatabaseFlags(cmd.Flags(), &dbOptions)
+	cmd.Flags().StringVar(&storePath, "store", "", "Read the transactions added with the import command from this SQLite store")
+	AddCacheFlags(cmd.Flags(), &cacheOptions)
+	cmd.Flags().StringVar(&dedupeMode, "dedupe", "off", "Handle transactions repeated across inputs: off, report (list them separately), drop or fail; repeats within one input are kept")
+	cmd.Flags().StringSliceVar(&dedupeKey, "dedupe-key", usecase.DefaultDedupeFields, "Fields identifying a duplicate: date, amount, content, category, id (e.g. OFX FITID); transactions missing one are matched by the default fields")
+	AddConfigFlags(cmd.Flags(), &configOptions)
+	AddWatchFlags(cmd.Flags(), &watchOptions)
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
 	cmd.Flags().IntVarP(&timeout, "timeout", "t", 30, "Timeout in seconds for processing (default: 30)")
 
-	_ = cmd.MarkFlagRequired("period")
-	_ = cmd.MarkFlagRequired("csv")
+	cmd.MarkFlagsMutuallyExclusive("period", "all-periods")
+	cmd.MarkFlagsMutuallyExclusive("out", "all-periods")
+	cmd.MarkFlagsRequiredTogether("all-periods", "out-dir")
+	cmd.MarkFlagsOneRequired("period", "all-periods")
+	cmd.MarkFlagsMutuallyExclusive("csv", "db", "store")
+	cmd.MarkFlagsOneRequired("csv", "db", "store")
+	cmd.MarkFlagsMutuallyExclusive("watch", "db")
+	cmd.MarkFlagsMutuallyExclusive("watch", "store")
+	cmd.MarkFlagsMutuallyExclusive("cache", "db")
+	cmd.MarkFlagsMutuallyExclusive("cache", "store")
+	cmd.MarkFlagsMutuallyExclusive("watch", "no-clobber")
 
 	return cmd
 }
+
+func generateAllPeriods(ctx context.Context, transactionService usecase.TransactionService, deduplicator *usecase.Deduplicator, uri, outDir, filenamePattern string, outputOptions OutputOptions) error {
+	if err := ValidateFilenamePattern(filenamePattern); err != nil {
+		return err
+	}
+
+	logger.Info("Generating statements for all periods", "out_dir", outDir, "pattern", filenamePattern)
+
+	batchService := usecase.NewBatchStatementService(transactionService, BatchWriterFactory(outDir, filenamePattern, outputOptions))
+	batchService.Deduplicator = deduplicator
+
+	periods, err := batchService.GenerateAllMonthlyStatements(ctx, uri)
+	if err != nil {
+		logger.Error("Failed to generate statements", "error", err, "written", len(periods))
+		return err
+	}
+
+	logger.Info("Statements generated successfully", "count", len(periods))
+	return nil
+}
+
+// createTransactionService reads transactions from --db or --store when given, from --csv otherwise
+func createTransactionService(ctx context.Context, inputs []string, inputOptions InputOptions, sourceOptions SourceOptions, s3Config objectstore.Config, dbOptions DatabaseOptions, storePath string, cacheOptions CacheOptions) (usecase.TransactionService, func(), error) {
+	if storePath != "" {
+		// Opening creates missing stores, which only the import command should do
+		if _, err := os.Stat(storePath); err != nil {
+			return nil, nil, domain.NewValidationError("transaction store not found, add transactions with the import command first", map[string]interface{}{
+				"store": storePath,
+			})
+		}
+		store, err := ledger.Open(ctx, storePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		logger.Debug("Reading transactions from store", "store", storePath)
+		return store, func() { _ = store.Close() }, nil
+	}
+	if dbOptions.Enabled() {
+		service, err := dbOptions.OpenTransactionService()
+		if err != nil {
+			return nil, nil, err
+		}
+		logger.Debug("Reading transactions from database", "driver", dbOptions.DriverName())
+		return service, func() { _ = service.DB.Close() }, nil
+	}
+
+	inputParser, err := inputOptions.CreateParser()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sourceOptions.S3 = s3Config
+	csvSource, err := sourceOptions.CreateSource()
+	if err != nil {
+		return nil, nil, err
+	}
+	logger.Debug("CSV inputs", "paths", inputs)
+
+	transactionService, err := cacheOptions.Wrap(usecase.NewTransactionService(csvSource, inputParser), inputOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inputs) > 1 {
+		return usecase.NewMergedTransactionService(transactionService, inputs), func() {}, nil
+	}
+	return transactionService, func() {}, nil
+}
+
+func hasFormat(targets []OutputTarget, format string) bool {
+	for _, target := range targets {
+		if target.Format == format {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNDJSONStreaming rejects NDJSON outputs that could not be streamed: the statement
+// would have to be built in memory first, which NDJSON output exists to avoid
+func checkNDJSONStreaming(targets []OutputTarget, allPeriods, database, dedupe, cache bool) error {
+	var conflict string
+	switch {
+	case len(targets) > 1:
+		conflict = "more than one output"
+	case objectstore.IsURI(targets[0].Path):
+		conflict = "s3:// outputs"
+	case allPeriods:
+		conflict = "--all-periods"
+	case database:
+		conflict = "--db and --store"
+	case dedupe:
+		conflict = "--dedupe"
+	case cache:
+		conflict = "--cache"
+	default:
+		return nil
+	}
+	return domain.NewValidationError(fmt.Sprintf("ndjson output is streamed and cannot be combined with %s; use json instead", conflict), map[string]interface{}{
+		"format": FormatNDJSON,
+	})
+}
+
+// streamNDJSON writes the statement of a period as NDJSON while the inputs are parsed
+func streamNDJSON(ctx context.Context, inputs []string, inputOptions InputOptions, sourceOptions SourceOptions, s3Config objectstore.Config, outputPath string, fileOptions output.FileOptions, display string, year, month int) error {
+	inputParser, err := inputOptions.CreateParser()
+	if err != nil {
+		return err
+	}
+	sourceOptions.S3 = s3Config
+	source, err := sourceOptions.CreateSource()
+	if err != nil {
+		return err
+	}
+
+	service := usecase.NewStreamingStatementService(source, inputParser)
+	return streamToTarget(outputPath, fileOptions, func(writer output.StreamWriter) error {
+		return service.StreamMonthlyStatement(ctx, inputs, display, year, month, writer)
+	})
+}
+
+func createDeduplicator(mode string, key []string) (*usecase.Deduplicator, error) {
+	dedupeMode, err := usecase.ParseDedupeMode(mode)
+	if err != nil || dedupeMode == usecase.DedupeOff {
+		return nil, err
+	}
+	return usecase.NewDeduplicator(dedupeMode, key)
+}
@@ -3,9 +3,12 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
-	"mf-statement/internal/adapters/in"
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
 	"mf-statement/internal/usecase"
 	"mf-statement/internal/util"
 
@@ -23,6 +26,12 @@ This command uses streaming parsing and early filtering to reduce memory usage.`
   # Generate with custom output file
   mf-statement generate-optimized --period 202501 --csv transactions.csv --out statement.json
   
+  # Stream newline-delimited JSON end to end without buffering the period
+  mf-statement generate-optimized --period 202501 --csv transactions.csv --format ndjson
+  
+  # Stream an NDJSON dump of an upstream API
+  mf-statement generate-optimized --period 202501 --csv transactions.ndjson --input-format ndjson --json-fields date=posted_at,content=memo
+  
   # Generate with verbose logging
   mf-statement generate-optimized --period 202501 --csv transactions.csv --verbose
   
@@ -32,17 +41,34 @@ This command uses streaming parsing and early filtering to reduce memory usage.`
 }
 
 var (
-	optimizedPeriod  string
-	optimizedCSV     string
-	optimizedOutput  string
-	optimizedVerbose bool
-	optimizedTimeout int
+	optimizedPeriod    string
+	optimizedCSV       string
+	optimizedOutputs   []string
+	optimizedTemplate  string
+	optimizedFormats   []string
+	optimizedNoClobber bool
+	optimizedFileMode  string
+	optimizedSource    SourceOptions
+	optimizedInput     InputOptions
+	optimizedS3        objectstore.Config
+	optimizedVerbose   bool
+	optimizedTimeout   int
 )
 
 func init() {
 	generateOptimizedCmd.Flags().StringVarP(&optimizedPeriod, "period", "p", "", "Month in YYYYMM format (e.g. 202501)")
-	generateOptimizedCmd.Flags().StringVarP(&optimizedCSV, "csv", "c", "", "Path to CSV file or file:// URI")
-	generateOptimizedCmd.Flags().StringVarP(&optimizedOutput, "out", "o", "", "Output JSON file path (default: stdout)")
+	generateOptimizedCmd.Flags().StringVarP(&optimizedCSV, "csv", "c", "", "Path to CSV file (gzip, bzip2, zstd or zip compressed allowed), - for stdin, file:// URI, http(s) URL or s3://bucket/key")
+	generateOptimizedCmd.Flags().StringArrayVarP(&optimizedOutputs, "out", "o", nil, "Output file path or s3://bucket/key, repeatable; - for stdout (default: stdout)")
+	generateOptimizedCmd.Flags().StringSliceVarP(&optimizedFormats, "format", "f", nil, "Output formats: json, ndjson, csv or template (a single ndjson output streams transactions in input order)")
+	generateOptimizedCmd.Flags().StringVar(&optimizedTemplate, "template", "", "Render the statement with a Go text/template file instead of JSON")
+	generateOptimizedCmd.Flags().BoolVar(&optimizedNoClobber, "no-clobber", false, "Fail instead of overwriting an existing output file")
+	generateOptimizedCmd.Flags().StringVar(&optimizedFileMode, "file-mode", "0644", "Permissions of the output file in octal")
+	generateOptimizedCmd.Flags().StringVar(&optimizedInput.Format, "input-format", InputFormatCSV, "Format of the --csv input: csv, json or ndjson")
+	generateOptimizedCmd.Flags().StringVar(&optimizedInput.Currency, "input-currency", "", "Currency of decimal JSON amounts with --json-amount-unit major (default: 2 decimals)")
+	AddCSVInputFlags(generateOptimizedCmd.Flags(), &optimizedInput.CSV)
+	AddJSONInputFlags(generateOptimizedCmd.Flags(), &optimizedInput.JSON)
+	AddSourceFlags(generateOptimizedCmd.Flags(), &optimizedSource)
+	AddS3Flags(generateOptimizedCmd.Flags(), &optimizedS3)
 	generateOptimizedCmd.Flags().BoolVarP(&optimizedVerbose, "verbose", "v", false, "Enable verbose logging")
 	generateOptimizedCmd.Flags().IntVarP(&optimizedTimeout, "timeout", "t", 30, "Timeout in seconds for processing (default: 30)")
 
@@ -68,14 +94,52 @@ func runGenerateOptimized(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(optimizedTimeout)*time.Second)
 	defer cancel()
 
-	if optimizedOutput != "" {
-		logger.Info("Output will be written to file", "file", optimizedOutput)
+	perm, err := ParseFileMode(optimizedFileMode)
+	if err != nil {
+		return err
+	}
+	outputOptions := OutputOptions{
+		Outputs:      optimizedOutputs,
+		Formats:      optimizedFormats,
+		TemplatePath: optimizedTemplate,
+		File:         output.FileOptions{Perm: perm, NoClobber: optimizedNoClobber},
+		S3:           optimizedS3,
 	}
+	targets, err := outputOptions.Targets()
+	if err != nil {
+		return err
+	}
+	outputOptions.LogTargets(logger)
 
 	// Create optimized services
-	source := in.NewCSVFileSource()
+	optimizedSource.S3 = optimizedS3
+	source, err := optimizedSource.CreateSource()
+	if err != nil {
+		return err
+	}
+	filteredParser, err := optimizedInput.CreateFilteredParser()
+	if err != nil {
+		return err
+	}
 	optimizedTransactionService := usecase.NewOptimizedTransactionService(source)
-	writer := CreateWriter(optimizedOutput)
+	optimizedTransactionService.FilteredParser = filteredParser
+
+	if len(targets) == 1 && targets[0].Format == FormatNDJSON && !objectstore.IsURI(targets[0].Path) {
+		// Stream transactions straight from the parser to the output
+		err = streamOptimized(ctx, usecase.NewOptimizedStatementService(optimizedTransactionService, nil), periodDisplay, year, month, targets[0].Path, outputOptions.File)
+		if err != nil {
+			logger.Error("Failed to generate statement", "error", err)
+			return err
+		}
+
+		logger.Info("Statement generated successfully")
+		return nil
+	}
+
+	writer, err := outputOptions.CreateWriter()
+	if err != nil {
+		return err
+	}
 	optimizedStatementService := usecase.NewOptimizedStatementService(optimizedTransactionService, writer)
 
 	// Generate statement with optimizations
@@ -88,3 +152,32 @@ func runGenerateOptimized(cmd *cobra.Command, args []string) error {
 	logger.Info("Statement generated successfully")
 	return nil
 }
+
+func streamOptimized(ctx context.Context, service *usecase.OptimizedStatementService, periodDisplay string, year, month int, outputPath string, fileOptions output.FileOptions) error {
+	return streamToTarget(outputPath, fileOptions, func(writer output.StreamWriter) error {
+		return service.StreamMonthlyStatement(ctx, optimizedCSV, periodDisplay, year, month, writer)
+	})
+}
+
+// streamToTarget hands stream an NDJSON writer over stdout or the output file; the file
+// replaces outputPath only when stream succeeds
+func streamToTarget(outputPath string, fileOptions output.FileOptions, stream func(output.StreamWriter) error) error {
+	if outputPath == "" {
+		return stream(output.NewNDJSON(os.Stdout))
+	}
+
+	file, err := output.CreateAtomic(outputPath, fileOptions)
+	if err != nil {
+		return domain.NewIOError("failed to create output file", err)
+	}
+	defer file.Close()
+
+	if err := stream(output.NewNDJSON(file)); err != nil {
+		return err
+	}
+
+	if err := file.Commit(); err != nil {
+		return domain.NewIOError("failed to write output file", err)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"mf-statement/internal/adapters/in"
 	"mf-statement/internal/adapters/out/output"
+	. "mf-statement/internal/cli"
 	"mf-statement/internal/usecase"
 )
 
@@ -70,6 +71,26 @@ var _ = Describe("GenerateOptimizedCommand", func() {
 			Expect(output).To(ContainSubstring(`"January Expense"`))
 		})
 
+		It("should stream NDJSON inputs through the filtered JSON parser", func() {
+			ndjsonPath := filepath.Join(tempDir, "transactions.ndjson")
+			ndjson := "{\"posted\":\"2025-01-01\",\"amount\":2000,\"memo\":\"January Salary\"}\n" +
+				"{\"posted\":\"2025-02-01\",\"amount\":2500,\"memo\":\"February Salary\"}\n"
+			Expect(os.WriteFile(ndjsonPath, []byte(ndjson), 0644)).To(Succeed())
+
+			inputOptions := InputOptions{Format: InputFormatNDJSON, JSON: JSONInputOptions{Fields: "date=posted,content=memo"}}
+			filteredParser, err := inputOptions.CreateFilteredParser()
+			Expect(err).NotTo(HaveOccurred())
+
+			optimizedTransactionService := usecase.NewOptimizedTransactionService(in.NewCSVFileSource())
+			optimizedTransactionService.FilteredParser = filteredParser
+
+			transactions, err := optimizedTransactionService.GetTransactionsByPeriodOptimized(ctx, ndjsonPath, 2025, 1)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions).To(HaveLen(1))
+			Expect(transactions[0].Content).To(Equal("January Salary"))
+		})
+
 		It("should filter transactions by period during parsing", func() {
 			// Create CSV with mixed periods
 			csvContent := `date,amount,content
@@ -1,13 +1,20 @@
 package cli_test
 
 import (
+	"compress/gzip"
+	"context"
+	"database/sql"
 	. "mf-statement/internal/cli"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/xuri/excelize/v2"
 )
 
 var _ = Describe("GenerateCommand", func() {
@@ -44,7 +51,30 @@ var _ = Describe("GenerateCommand", func() {
 
 			err := cmd.Execute()
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("required flag(s)"))
+			// --csv, --db and --store form a group of which one is required
+			Expect(err.Error()).To(ContainSubstring("[csv db store] is required"))
+		})
+
+		It("should reject --csv together with --db", func() {
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--db", "ledger.db"})
+
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("none of the others can be")))
+		})
+
+		It("should reject a --db SQLite file that does not exist", func() {
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--db", filepath.Join(tempDir, "missing.db")})
+
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("missing.db not found")))
+			Expect(filepath.Join(tempDir, "missing.db")).NotTo(BeAnExistingFile())
+		})
+
+		It("should reject input arguments together with --store", func() {
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--store", "ledger.db", csvPath})
+
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("input arguments cannot be combined with --store")))
 		})
 	})
 
@@ -81,5 +111,366 @@ var _ = Describe("GenerateCommand", func() {
 			Expect(string(data)).To(ContainSubstring(`"total_income": 1000`))
 			Expect(string(data)).To(ContainSubstring(`"total_expenditure": -200`))
 		}, SpecTimeout(5*time.Second))
+
+		It("should read local inputs when the S3 configuration is unusable", func(ctx SpecContext) {
+			outPath := filepath.Join(tempDir, "statement.json")
+
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--out", outPath, "--s3-endpoint", "ftp://storage"})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+			Expect(outPath).To(BeAnExistingFile())
+		}, SpecTimeout(5*time.Second))
+
+		It("should write every --out destination from a single run", func(ctx SpecContext) {
+			jsonPath := filepath.Join(tempDir, "statement.json")
+			csvOutPath := filepath.Join(tempDir, "statement.csv")
+
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--out", jsonPath, "--out", csvOutPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(jsonPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"period": "2025/01"`))
+
+			data, err = os.ReadFile(csvOutPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(HavePrefix("date,amount,content\n"))
+		}, SpecTimeout(5*time.Second))
+
+		It("should stream --format ndjson in input order followed by the summary", func(ctx SpecContext) {
+			outPath := filepath.Join(tempDir, "statement.ndjson")
+
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--format", "ndjson", "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(ContainSubstring(`"content":"Salary"`))
+			Expect(lines[1]).To(ContainSubstring(`"content":"Groceries"`))
+			Expect(lines[2]).To(ContainSubstring(`"type":"summary"`))
+			Expect(lines[2]).To(ContainSubstring(`"total_expenditure":-200`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should reject --format ndjson with --dedupe", func(ctx SpecContext) {
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--format", "ndjson", "--dedupe", "drop"})
+
+			Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("cannot be combined with --dedupe")))
+		}, SpecTimeout(5*time.Second))
+
+		It("should download the CSV from an http URL with headers", func(ctx SpecContext) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(csvContent))
+			}))
+			defer server.Close()
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", server.URL + "/transactions.csv", "--header", "Authorization: Bearer token", "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_income": 1000`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should read transactions from a SQLite database", func(ctx SpecContext) {
+			dbPath := filepath.Join(tempDir, "ledger.db")
+			db, err := sql.Open("sqlite", dbPath)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = db.Exec(`
+				CREATE TABLE entries (posted_on TEXT, cents INTEGER, memo TEXT);
+				INSERT INTO entries VALUES ('2025-01-01', 1000, 'Salary'), ('2025-01-05', -200, 'Groceries'), ('2025-02-01', -50, 'Bus');
+			`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(db.Close()).To(Succeed())
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--db", dbPath,
+				"--db-query", "SELECT posted_on, cents, memo FROM entries",
+				"--db-columns", "date=posted_on,amount=cents,content=memo",
+				"--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_income": 1000`))
+			Expect(string(data)).To(ContainSubstring(`"total_expenditure": -200`))
+			Expect(string(data)).NotTo(ContainSubstring("Bus"))
+		}, SpecTimeout(5*time.Second))
+
+		It("should read gzip-compressed CSV from stdin with --csv -", func(ctx SpecContext) {
+			compressedPath := filepath.Join(tempDir, "transactions.csv.gz")
+			file, err := os.Create(compressedPath)
+			Expect(err).NotTo(HaveOccurred())
+			gz := gzip.NewWriter(file)
+			_, err = gz.Write([]byte(csvContent))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gz.Close()).To(Succeed())
+			Expect(file.Close()).To(Succeed())
+
+			stdin, err := os.Open(compressedPath)
+			Expect(err).NotTo(HaveOccurred())
+			defer stdin.Close()
+			originalStdin := os.Stdin
+			os.Stdin = stdin
+			defer func() { os.Stdin = originalStdin }()
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", "-", "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_income": 1000`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should merge repeated and globbed inputs into one statement", func(ctx SpecContext) {
+			exportsDir := filepath.Join(tempDir, "exports")
+			Expect(os.MkdirAll(exportsDir, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(exportsDir, "savings-2025-01.csv"), []byte("date,amount,content\n2025/01/20,50,Interest\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(exportsDir, "card-2025-01.csv"), []byte("date,amount,content\n2025/01/21,-75,Books\n"), 0644)).To(Succeed())
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--csv", filepath.Join(exportsDir, "*-2025-01.csv"), "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_income": 1050`))
+			Expect(string(data)).To(ContainSubstring(`"total_expenditure": -275`))
+			Expect(string(data)).To(ContainSubstring(`"source": "` + filepath.Join(exportsDir, "card-2025-01.csv") + `"`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should report transactions repeated across inputs with --dedupe report", func(ctx SpecContext) {
+			overlapPath := filepath.Join(tempDir, "overlap.csv")
+			Expect(os.WriteFile(overlapPath, []byte("date,amount,content\n2025/01/05,-200,GROCERIES\n2025/01/06,-10,Coffee\n"), 0644)).To(Succeed())
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--csv", overlapPath, "--dedupe", "report", "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_expenditure": -210`))
+			Expect(string(data)).To(ContainSubstring(`"duplicates": [`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should fail on duplicates with --dedupe fail", func(ctx SpecContext) {
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--csv", "file://" + csvPath, "--dedupe", "fail"})
+
+			Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("duplicate transactions")))
+		}, SpecTimeout(5*time.Second))
+
+		It("should merge an OFX statement with a CSV by detecting its format", func(ctx SpecContext) {
+			ofxPath := filepath.Join(tempDir, "checking.qfx")
+			ofx := "OFXHEADER:100\nDATA:OFXSGML\n\n<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>USD<BANKTRANLIST>" +
+				"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20250112<TRNAMT>-4.25<FITID>1<NAME>Bakery</STMTTRN>" +
+				"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>"
+			Expect(os.WriteFile(ofxPath, []byte(ofx), 0644)).To(Succeed())
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--csv", ofxPath, "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_expenditure": -625`))
+			Expect(string(data)).To(ContainSubstring(`"id": "1"`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should read a QIF export with --qif-date-format", func(ctx SpecContext) {
+			qifPath := filepath.Join(tempDir, "legacy.qif")
+			Expect(os.WriteFile(qifPath, []byte("!Type:CCard\nD12/01/2025\nT-1,500\nPLunch\nLFood\n^\n"), 0644)).To(Succeed())
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", qifPath, "--input-format", "qif", "--qif-date-format", "dmy", "--input-currency", "JPY", "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_expenditure": -1500`))
+			Expect(string(data)).To(ContainSubstring(`"category": "Food"`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should reject an MT940 statement that does not reconcile with --reconcile", func(ctx SpecContext) {
+			mt940Path := filepath.Join(tempDir, "statement.sta")
+			mt940 := ":20:JAN\n:25:123/456\n:60F:C241231EUR100,00\n:61:250105D20,00NTRFNONREF\n:86:Rent\n:62F:C250131EUR90,00\n-\n"
+			Expect(os.WriteFile(mt940Path, []byte(mt940), 0644)).To(Succeed())
+
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", mt940Path})
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			cmd = NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", mt940Path, "--reconcile"})
+			Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("does not reconcile")))
+		}, SpecTimeout(5*time.Second))
+
+		It("should read a JSON dump with --json-fields", func(ctx SpecContext) {
+			jsonPath := filepath.Join(tempDir, "dump.json")
+			Expect(os.WriteFile(jsonPath, []byte(`{"items": [{"posted_at": "2025-01-12T09:00:00Z", "total": "-4.25", "memo": "Bakery"}]}`), 0644)).To(Succeed())
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", jsonPath, "--json-root", "items", "--json-fields", "date=posted_at,amount=total,content=memo", "--json-amount-unit", "major", "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_expenditure": -425`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should read an XLSX export with --xlsx-columns", func(ctx SpecContext) {
+			workbook := excelize.NewFile()
+			Expect(workbook.SetSheetRow("Sheet1", "A1", &[]interface{}{"Bank export"})).To(Succeed())
+			Expect(workbook.SetSheetRow("Sheet1", "A3", &[]interface{}{"Booking Date", "Description", "Amount"})).To(Succeed())
+			Expect(workbook.SetSheetRow("Sheet1", "A4", &[]interface{}{time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC), "Bakery", -4.25})).To(Succeed())
+			xlsxPath := filepath.Join(tempDir, "export.xlsx")
+			Expect(workbook.SaveAs(xlsxPath)).To(Succeed())
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", xlsxPath, "--xlsx-columns", "date=Booking Date,content=Description", "--xlsx-amount-unit", "major", "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_expenditure": -425`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should detect tab-separated exports by extension and content", func(ctx SpecContext) {
+			tsvPath := filepath.Join(tempDir, "export.tsv")
+			Expect(os.WriteFile(tsvPath, []byte("date\tamount\tcontent\n2025/01/12\t-425\tBakery\n"), 0644)).To(Succeed())
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", tsvPath, "--csv", csvPath, "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("Bakery"))
+		}, SpecTimeout(5*time.Second))
+
+		It("should read semicolon-separated exports with --csv-delimiter", func(ctx SpecContext) {
+			exportPath := filepath.Join(tempDir, "export.csv")
+			Expect(os.WriteFile(exportPath, []byte("Umsätze Januar\ndate;amount;content\n2025/01/12;-425;Bäckerei\n;-425;Summe\n"), 0644)).To(Succeed())
+
+			outPath := filepath.Join(tempDir, "statement.json")
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", exportPath, "--csv-delimiter", ";", "--csv-skip-lines", "1", "--csv-skip-trailing", "1", "--out", outPath})
+
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+			data, err := os.ReadFile(outPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"total_expenditure": -425`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should regenerate the output when a watched directory changes", func(ctx SpecContext) {
+			exportsDir := filepath.Join(tempDir, "exports")
+			Expect(os.Mkdir(exportsDir, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(exportsDir, "morning.csv"), []byte("date,amount,content\n2025/01/05,-200,Groceries\n"), 0644)).To(Succeed())
+			outPath := filepath.Join(tempDir, "statement.json")
+
+			watchCtx, stop := context.WithCancel(ctx)
+			done := make(chan error, 1)
+			go func() {
+				cmd := NewGenerateCommand()
+				cmd.SetArgs([]string{"--period", "202501", "--csv", exportsDir, "--out", outPath, "--watch", "--watch-debounce", "50ms"})
+				done <- cmd.ExecuteContext(watchCtx)
+			}()
+
+			Eventually(func() (string, error) {
+				data, err := os.ReadFile(outPath)
+				return string(data), err
+			}, "2s").Should(ContainSubstring(`"total_expenditure": -200`))
+
+			Expect(os.WriteFile(filepath.Join(exportsDir, "evening.csv"), []byte("date,amount,content\n2025/01/06,-300,Fuel\n"), 0644)).To(Succeed())
+
+			Eventually(func() (string, error) {
+				data, err := os.ReadFile(outPath)
+				return string(data), err
+			}, "2s").Should(ContainSubstring(`"total_expenditure": -500`))
+
+			stop()
+			Eventually(done, "2s").Should(Receive(BeNil()))
+		}, SpecTimeout(10*time.Second))
+
+		It("should index inputs with --cache and answer other periods from the index", func(ctx SpecContext) {
+			cacheDir := filepath.Join(tempDir, "cache")
+			Expect(os.WriteFile(csvPath, []byte(csvContent+"2025/02/03,-80,Books\n"), 0644)).To(Succeed())
+
+			for _, period := range []string{"202501", "202502"} {
+				cmd := NewGenerateCommand()
+				cmd.SetArgs([]string{"--period", period, "--csv", csvPath, "--cache", "--cache-dir", cacheDir, "--out", filepath.Join(tempDir, period+".json")})
+				Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+			}
+
+			Expect(filepath.Glob(filepath.Join(cacheDir, "*.idx"))).To(HaveLen(1))
+			january, err := os.ReadFile(filepath.Join(tempDir, "202501.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(january)).To(ContainSubstring(`"total_expenditure": -200`))
+			february, err := os.ReadFile(filepath.Join(tempDir, "202502.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(february)).To(ContainSubstring(`"total_expenditure": -80`))
+		}, SpecTimeout(5*time.Second))
+
+		It("should refuse to watch inputs that cannot be watched", func(ctx SpecContext) {
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", "https://example.com/transactions.csv", "--watch"})
+			Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("--watch needs local")))
+
+			cmd = NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", tempDir, "--out", filepath.Join(tempDir, "statement.json"), "--watch"})
+			Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("would regenerate itself")))
+		}, SpecTimeout(5*time.Second))
+
+		It("should refuse to watch inputs overlapping the --out-dir of --all-periods", func(ctx SpecContext) {
+			for _, args := range [][]string{
+				{"--csv", tempDir, "--out-dir", filepath.Join(tempDir, "statements")},
+				{"--csv", filepath.Join(tempDir, "*.csv"), "--out-dir", tempDir},
+			} {
+				cmd := NewGenerateCommand()
+				cmd.SetArgs(append([]string{"--all-periods", "--watch"}, args...))
+				Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("--out-dir overlaps the watched inputs")))
+			}
+		}, SpecTimeout(5*time.Second))
+
+		It("should reject an unknown --input-format", func(ctx SpecContext) {
+			cmd := NewGenerateCommand()
+			cmd.SetArgs([]string{"--period", "202501", "--csv", csvPath, "--input-format", "pdf"})
+
+			Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("unsupported input format")))
+		}, SpecTimeout(5*time.Second))
 	})
 })
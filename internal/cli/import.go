@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"mf-statement/internal/adapters/ledger"
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+	"mf-statement/internal/util"
+)
+
+// DefaultStorePath is the transaction store of the import command when --store is not given
+const DefaultStorePath = "mf-statement.db"
+
+func NewImportCommand() *cobra.Command {
+	var (
+		storePath     string
+		csvPaths      []string
+		sourceOptions SourceOptions
+		inputOptions  InputOptions
+		s3Config      objectstore.Config
+		verbose       bool
+		timeout       int
+		configOptions ConfigOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Add transactions to the local transaction store",
+		Long: `Parses inputs and adds their transactions to a SQLite transaction store, from which
+generate --store builds statements for any imported period.
+
+Importing is idempotent: inputs whose content was imported before are skipped, and rows
+that an earlier import already stored, as in overlapping monthly exports, are not added
+again. Equal transactions within one input, such as two identical purchases on the same
+day, are all kept.`,
+		Example: `  # Import this month's export into mf-statement.db
+  mf-statement import --csv exports/2025-01.csv
+
+  # Import every export of a directory into a shared ledger
+  mf-statement import --store ledger.db --csv exports/
+
+  # Generate a statement from everything imported so far
+  mf-statement generate --period 202501 --store ledger.db`,
+		PreRunE: ApplyConfig(&configOptions, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inputs, err := ExpandInputs(append(csvPaths, args...))
+			if err != nil {
+				return err
+			}
+			if len(inputs) == 0 {
+				_ = cmd.Help()
+				return domain.NewValidationError("missing inputs to import", nil)
+			}
+
+			if verbose {
+				logger = util.NewDebugLogger()
+			}
+
+			inputParser, err := inputOptions.CreateParser()
+			if err != nil {
+				return err
+			}
+			sourceOptions.S3 = s3Config
+			source, err := sourceOptions.CreateSource()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+			defer cancel()
+
+			store, err := ledger.Open(ctx, storePath)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			importService := usecase.NewImportService(source, inputParser, store)
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "INPUT\tROWS\tNEW\tDUPLICATES\tSTATUS")
+			for _, input := range inputs {
+				result, err := importService.Import(ctx, input)
+				if err != nil {
+					_ = w.Flush()
+					logger.Error("Failed to import input", "input", input, "error", err)
+					return err
+				}
+				if result.Skipped {
+					fmt.Fprintf(w, "%s\t-\t-\t-\talready imported\n", input)
+					continue
+				}
+				fmt.Fprintf(w, "%s\t%d\t%d\t%d\timported\n", input, result.Rows, result.Added, result.Duplicates())
+				logger.Debug("Imported input", "input", input, "hash", result.Hash, "added", result.Added)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&storePath, "store", DefaultStorePath, "SQLite file accumulating the imported transactions, created when missing")
+	cmd.Flags().StringArrayVarP(&csvPaths, "csv", "c", nil, "Path, glob or directory of inputs to import, repeatable; - for stdin, file:// URI, http(s) URL or s3://bucket/key")
+	AddInputFlags(cmd.Flags(), &inputOptions)
+	AddSourceFlags(cmd.Flags(), &sourceOptions)
+	AddS3Flags(cmd.Flags(), &s3Config)
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
+	cmd.Flags().IntVarP(&timeout, "timeout", "t", 30, "Timeout in seconds for processing (default: 30)")
+	AddConfigFlags(cmd.Flags(), &configOptions)
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/cli"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("ImportCommand", func() {
+	var (
+		tempDir   string
+		storePath string
+	)
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		storePath = filepath.Join(tempDir, "ledger.db")
+		Expect(os.WriteFile(filepath.Join(tempDir, "december.csv"), []byte("date,amount,content\n2024/12/30,-300,Coffee\n2025/01/02,-200,Groceries\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tempDir, "january.csv"), []byte("date,amount,content\n2025/01/02,-200,Groceries\n2025/01/05,1000,Salary\n"), 0644)).To(Succeed())
+
+		cli.NewRootCommand()
+	})
+
+	runImport := func(args ...string) string {
+		var out bytes.Buffer
+		cmd := cli.NewImportCommand()
+		cmd.SetOut(&out)
+		cmd.SetArgs(append([]string{"--store", storePath}, args...))
+		Expect(cmd.Execute()).To(Succeed())
+		return out.String()
+	}
+
+	It("should import overlapping exports once and generate statements from the store", func(ctx SpecContext) {
+		out := runImport("--csv", filepath.Join(tempDir, "december.csv"), "--csv", filepath.Join(tempDir, "january.csv"))
+		Expect(out).To(MatchRegexp(`december\.csv\s+2\s+2\s+0\s+imported`))
+		Expect(out).To(MatchRegexp(`january\.csv\s+2\s+1\s+1\s+imported`))
+
+		Expect(runImport(filepath.Join(tempDir, "january.csv"))).To(MatchRegexp(`january\.csv\s+-\s+-\s+-\s+already imported`))
+
+		outPath := filepath.Join(tempDir, "statement.json")
+		cmd := cli.NewGenerateCommand()
+		cmd.SetArgs([]string{"--period", "202501", "--store", storePath, "--out", outPath})
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+		data, err := os.ReadFile(outPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"total_income": 1000`))
+		Expect(string(data)).To(ContainSubstring(`"total_expenditure": -200`))
+	}, SpecTimeout(5*time.Second))
+
+	It("should not create missing stores when generating", func(ctx SpecContext) {
+		cmd := cli.NewGenerateCommand()
+		cmd.SetArgs([]string{"--period", "202501", "--store", storePath})
+
+		err := cmd.ExecuteContext(ctx)
+
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+		Expect(storePath).NotTo(BeAnExistingFile())
+	}, SpecTimeout(5*time.Second))
+
+	It("should require inputs", func() {
+		cmd := cli.NewImportCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--store", storePath})
+
+		Expect(domain.IsValidationError(cmd.Execute())).To(BeTrue())
+	})
+})
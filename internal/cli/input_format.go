@@ -0,0 +1,315 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"mf-statement/internal/adapters/in"
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// Input formats accepted by --input-format
+const (
+	InputFormatAuto    = "auto"
+	InputFormatCSV     = "csv"
+	InputFormatTSV     = "tsv"
+	InputFormatOFX     = "ofx"
+	InputFormatQIF     = "qif"
+	InputFormatCamt053 = "camt053"
+	InputFormatMT940   = "mt940"
+	InputFormatJSON    = "json"
+	InputFormatNDJSON  = "ndjson"
+	InputFormatXLSX    = "xlsx"
+)
+
+// InputOptions collects the flags selecting and configuring the input parser
+type InputOptions struct {
+	Format        string
+	Currency      string
+	QIFDateFormat string
+	Reconcile     bool
+	CSV           CSVInputOptions
+	JSON          JSONInputOptions
+	XLSX          XLSXInputOptions
+}
+
+// CSVInputOptions configures the dialect of delimited inputs
+type CSVInputOptions struct {
+	Delimiter    string
+	Comment      string
+	SkipLines    int
+	LazyQuotes   bool
+	SkipTrailing int
+}
+
+// JSONInputOptions configures how JSON and NDJSON records map to transactions
+type JSONInputOptions struct {
+	Fields     string
+	Root       string
+	DateFormat string
+	AmountUnit string
+}
+
+// AddInputFlags registers the flags configuring how inputs are parsed
+func AddInputFlags(flags *pflag.FlagSet, options *InputOptions) {
+	flags.StringVar(&options.Format, "input-format", InputFormatAuto, "Format of the inputs: "+strings.Join(in.NewDefaultParserRegistry().Names(), ", ")+"; auto detects it from the content and file extension (see the formats command)")
+	flags.StringVar(&options.Currency, "input-currency", "", "Currency of inputs with decimal amounts but no currency, such as QIF; sets the minor units amounts are converted to (default: 2 decimals)")
+	flags.StringVar(&options.QIFDateFormat, "qif-date-format", string(parser.QIFDateMDY), "Order of QIF date parts: mdy, dmy or ymd")
+	flags.BoolVar(&options.Reconcile, "reconcile", false, "Fail when the opening and closing balances of camt.053 or MT940 statements do not match their transactions")
+	AddCSVInputFlags(flags, &options.CSV)
+	AddJSONInputFlags(flags, &options.JSON)
+	flags.StringVar(&options.XLSX.Sheet, "xlsx-sheet", "", "Worksheet of XLSX inputs (default: the first sheet)")
+	flags.StringVar(&options.XLSX.Columns, "xlsx-columns", "", "XLSX header names as field=header pairs, e.g. date=Booking Date,amount=Amount,content=Description (default: date, amount, content, category)")
+	flags.StringVar(&options.XLSX.AmountUnit, "xlsx-amount-unit", string(parser.JSONAmountMinor), "XLSX amounts are minor units like the CSV, or major: decimals converted by --input-currency")
+}
+
+// XLSXInputOptions configures which sheet and columns of a workbook are read
+type XLSXInputOptions struct {
+	Sheet      string
+	Columns    string
+	AmountUnit string
+}
+
+// AddCSVInputFlags registers the flags describing the dialect of delimited inputs
+func AddCSVInputFlags(flags *pflag.FlagSet, options *CSVInputOptions) {
+	flags.StringVar(&options.Delimiter, "csv-delimiter", ",", "Field delimiter of CSV inputs, a single character or \"tab\"")
+	flags.StringVar(&options.Comment, "csv-comment", "", "Character starting comment lines to ignore in CSV inputs, e.g. #")
+	flags.IntVar(&options.SkipLines, "csv-skip-lines", 0, "Number of preamble lines before the CSV header")
+	flags.BoolVar(&options.LazyQuotes, "csv-lazy-quotes", false, "Accept stray quotes in CSV fields")
+	flags.IntVar(&options.SkipTrailing, "csv-skip-trailing", 0, "Number of summary rows at the end of CSV inputs to ignore, such as totals")
+}
+
+// Dialect returns the CSV dialect described by the flags
+func (o CSVInputOptions) Dialect() (parser.CSVDialect, error) {
+	dialect := parser.CSVDialect{SkipLines: o.SkipLines, LazyQuotes: o.LazyQuotes, SkipTrailing: o.SkipTrailing}
+
+	var err error
+	if dialect.Comma, err = parseDialectRune("delimiter", o.Delimiter); err != nil {
+		return parser.CSVDialect{}, err
+	}
+	if dialect.Comment, err = parseDialectRune("comment", o.Comment); err != nil {
+		return parser.CSVDialect{}, err
+	}
+	if err := dialect.Validate(); err != nil {
+		return parser.CSVDialect{}, err
+	}
+	return dialect, nil
+}
+
+// parseDialectRune reads a single character; "tab" and \t name the tab
+func parseDialectRune(name, value string) (rune, error) {
+	switch value {
+	case "":
+		return 0, nil
+	case "tab", `\t`:
+		return '\t', nil
+	}
+	runes := []rune(value)
+	if len(runes) != 1 {
+		return 0, domain.NewValidationError(fmt.Sprintf("CSV %s must be a single character", name), map[string]interface{}{
+			name: value,
+		})
+	}
+	return runes[0], nil
+}
+
+// AddJSONInputFlags registers the flags mapping JSON records to transactions
+func AddJSONInputFlags(flags *pflag.FlagSet, options *JSONInputOptions) {
+	flags.StringVar(&options.Fields, "json-fields", "", "Dotted paths of JSON record fields as field=path pairs, e.g. date=posted_at,amount=amount.value,content=description (default: date, amount, content, category, id)")
+	flags.StringVar(&options.Root, "json-root", "", "Dotted path of the records array within a JSON document, e.g. data.transactions")
+	flags.StringVar(&options.DateFormat, "json-date-format", "", "Go layout of JSON dates (default: 2006/01/02, 2006-01-02 or RFC 3339)")
+	flags.StringVar(&options.AmountUnit, "json-amount-unit", string(parser.JSONAmountMinor), "JSON amounts are minor units like the CSV, or major: decimals converted by --input-currency")
+}
+
+// CreateParser returns the parser of the input format; auto detects the format of
+// each input from its content and file extension
+func (o InputOptions) CreateParser() (usecase.Parser, error) {
+	registry, err := o.CreateParserRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return registry.Parser(o.Format)
+}
+
+// CreateParserRegistry returns the supported input formats with their parsers
+// configured by the flags
+func (o InputOptions) CreateParserRegistry() (*in.ParserRegistry, error) {
+	dateFormat, err := parser.ParseQIFDateFormat(o.QIFDateFormat)
+	if err != nil {
+		return nil, err
+	}
+	jsonParser, err := o.createJSONParser()
+	if err != nil {
+		return nil, err
+	}
+	xlsx, err := o.createXLSXParser()
+	if err != nil {
+		return nil, err
+	}
+	dialect, err := o.CSV.Dialect()
+	if err != nil {
+		return nil, err
+	}
+	tsvDialect := dialect
+	tsvDialect.Comma = '\t'
+
+	registry := in.NewDefaultParserRegistry()
+	registry.SetParser(InputFormatCSV, &parser.CSVParser{Dialect: dialect})
+	registry.SetParser(InputFormatTSV, &parser.CSVParser{Dialect: tsvDialect})
+	registry.SetParser(InputFormatQIF, &parser.QIFParser{DateFormat: dateFormat, MinorUnits: parser.MinorUnits(o.Currency)})
+	registry.SetParser(InputFormatCamt053, &parser.Camt053Parser{Reconcile: o.Reconcile})
+	registry.SetParser(InputFormatMT940, &parser.MT940Parser{Reconcile: o.Reconcile})
+	registry.SetParser(InputFormatJSON, jsonParser)
+	registry.SetParser(InputFormatXLSX, xlsx)
+	return registry, nil
+}
+
+// CreateFilteredParser returns the streaming parser of generate-optimized, which
+// reads CSV or JSON
+func (o InputOptions) CreateFilteredParser() (usecase.FilteredParser, error) {
+	switch strings.ToLower(o.Format) {
+	case InputFormatCSV, InputFormatAuto, "":
+		dialect, err := o.CSV.Dialect()
+		if err != nil {
+			return nil, err
+		}
+		return &parser.FilteredCSVParser{Dialect: dialect}, nil
+	case InputFormatJSON, InputFormatNDJSON:
+		jsonParser, err := o.createJSONParser()
+		if err != nil {
+			return nil, err
+		}
+		return parser.NewFilteredJSON(jsonParser), nil
+	default:
+		return nil, domain.NewValidationError("unsupported input format for streaming", map[string]interface{}{
+			"format":  o.Format,
+			"allowed": []string{InputFormatCSV, InputFormatJSON, InputFormatNDJSON},
+		})
+	}
+}
+
+func (o InputOptions) createJSONParser() (*parser.JSONParser, error) {
+	fields, err := ParseJSONFields(o.JSON.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	unit, err := parseAmountUnit(o.JSON.AmountUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser.JSONParser{
+		Fields:     fields,
+		Root:       o.JSON.Root,
+		DateFormat: o.JSON.DateFormat,
+		AmountUnit: unit,
+		MinorUnits: parser.MinorUnits(o.Currency),
+	}, nil
+}
+
+func (o InputOptions) createXLSXParser() (*parser.XLSXParser, error) {
+	columns, err := ParseXLSXColumns(o.XLSX.Columns)
+	if err != nil {
+		return nil, err
+	}
+	unit, err := parseAmountUnit(o.XLSX.AmountUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser.XLSXParser{
+		Sheet:      o.XLSX.Sheet,
+		Columns:    columns,
+		AmountUnit: unit,
+		MinorUnits: parser.MinorUnits(o.Currency),
+	}, nil
+}
+
+func parseAmountUnit(unit string) (parser.JSONAmountUnit, error) {
+	switch parser.JSONAmountUnit(strings.ToLower(unit)) {
+	case parser.JSONAmountMinor, "":
+		return parser.JSONAmountMinor, nil
+	case parser.JSONAmountMajor:
+		return parser.JSONAmountMajor, nil
+	default:
+		return "", domain.NewValidationError("invalid amount unit", map[string]interface{}{
+			"unit":    unit,
+			"allowed": []parser.JSONAmountUnit{parser.JSONAmountMinor, parser.JSONAmountMajor},
+		})
+	}
+}
+
+// ParseXLSXColumns parses field=header pairs on top of the default header names
+func ParseXLSXColumns(mapping string) (parser.XLSXColumns, error) {
+	columns := parser.DefaultXLSXColumns()
+	if mapping == "" {
+		return columns, nil
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		field, header, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		header = strings.TrimSpace(header)
+		if !ok || header == "" {
+			return parser.XLSXColumns{}, domain.NewValidationError("XLSX column mapping must be field=header", map[string]interface{}{
+				"mapping": pair,
+			})
+		}
+
+		switch strings.TrimSpace(field) {
+		case "date":
+			columns.Date = header
+		case "amount":
+			columns.Amount = header
+		case "content":
+			columns.Content = header
+		case "category":
+			columns.Category = header
+		default:
+			return parser.XLSXColumns{}, domain.NewValidationError("unknown transaction field in XLSX column mapping", map[string]interface{}{
+				"field": field,
+			})
+		}
+	}
+	return columns, nil
+}
+
+// ParseJSONFields parses field=path pairs on top of the default field paths
+func ParseJSONFields(mapping string) (parser.JSONFields, error) {
+	fields := parser.DefaultJSONFields()
+	if mapping == "" {
+		return fields, nil
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		field, path, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		path = strings.TrimSpace(path)
+		if !ok || path == "" {
+			return parser.JSONFields{}, domain.NewValidationError("JSON field mapping must be field=path", map[string]interface{}{
+				"mapping": pair,
+			})
+		}
+
+		switch strings.TrimSpace(field) {
+		case "date":
+			fields.Date = path
+		case "amount":
+			fields.Amount = path
+		case "content":
+			fields.Content = path
+		case "category":
+			fields.Category = path
+		case "id":
+			fields.ID = path
+		default:
+			return parser.JSONFields{}, domain.NewValidationError("unknown transaction field in JSON field mapping", map[string]interface{}{
+				"field": field,
+			})
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/util"
+)
+
+// StdoutPath is the --out value that selects standard output
+const StdoutPath = "-"
+
+// OutputOptions collects the output flags shared by the generate commands
+type OutputOptions struct {
+	Outputs      []string
+	Formats      []string
+	TemplatePath string
+	File         output.FileOptions
+	S3           objectstore.Config
+}
+
+// OutputTarget is a single resolved output destination; an empty Path means stdout
+type OutputTarget struct {
+	Path   string
+	Format string
+}
+
+func (t OutputTarget) Name() string {
+	if t.Path == "" {
+		return "stdout"
+	}
+	return t.Path
+}
+
+// FormatForPath infers the output format of a path. A configured template renders
+// every output, whatever its extension; otherwise the extension decides and unknown
+// extensions and stdout get JSON.
+func FormatForPath(path, templatePath string) string {
+	if templatePath != "" {
+		return FormatTemplate
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	case ".csv":
+		return FormatCSV
+	}
+	return FormatJSON
+}
+
+// Targets pairs every --out value with its format. Formats are either inferred
+// from the paths, given once for all outputs, or given once per output.
+func (o OutputOptions) Targets() ([]OutputTarget, error) {
+	outputs := o.Outputs
+	if len(outputs) == 0 {
+		outputs = []string{StdoutPath}
+	}
+
+	formats := o.Formats
+	if len(formats) > 1 && len(o.Outputs) == 0 {
+		return nil, domain.NewValidationError("several formats need one --out per format", map[string]interface{}{
+			"formats": formats,
+		})
+	}
+	if len(formats) > 1 && len(formats) != len(outputs) {
+		return nil, domain.NewValidationError("number of formats must be 1 or match the number of outputs", map[string]interface{}{
+			"formats": formats,
+			"outputs": outputs,
+		})
+	}
+
+	stdout := 0
+	targets := make([]OutputTarget, len(outputs))
+	for i, out := range outputs {
+		path := out
+		if out == StdoutPath {
+			path = ""
+			stdout++
+		}
+		if stdout > 1 {
+			// Several documents on stdout would run together into one unreadable stream
+			return nil, domain.NewValidationError("only one output can be written to stdout", map[string]interface{}{
+				"outputs": outputs,
+			})
+		}
+
+		var format string
+		switch len(formats) {
+		case 0:
+			format = FormatForPath(path, o.TemplatePath)
+		case 1:
+			format = formats[0]
+		default:
+			format = formats[i]
+		}
+
+		if format == FormatTemplate && o.TemplatePath == "" {
+			return nil, domain.NewValidationError("template format requires --template", map[string]interface{}{
+				"output": out,
+			})
+		}
+
+		targets[i] = OutputTarget{Path: path, Format: format}
+	}
+	return targets, nil
+}
+
+// CreateWriter builds the writer for all targets, fanning out when there is more than one
+func (o OutputOptions) CreateWriter() (output.Writer, error) {
+	targets, err := o.Targets()
+	if err != nil {
+		return nil, err
+	}
+
+	destinations := make([]output.Destination, len(targets))
+	for i, target := range targets {
+		var writer output.Writer
+		if objectstore.IsURI(target.Path) {
+			writer, err = o.createS3Writer(target)
+		} else if target.Format == FormatTemplate {
+			writer, err = CreateTemplateWriter(target.Path, o.TemplatePath, o.File)
+		} else {
+			writer, err = CreateFormatWriter(target.Path, target.Format, o.File)
+		}
+		if err != nil {
+			return nil, err
+		}
+		destinations[i] = output.Destination{Name: target.Name(), Writer: writer}
+	}
+
+	if len(destinations) == 1 {
+		return destinations[0].Writer, nil
+	}
+	return output.NewMulti(destinations...), nil
+}
+
+// LogTargets reports where the output goes and warns about files that will be replaced
+func (o OutputOptions) LogTargets(logger *util.Logger) {
+	targets, err := o.Targets()
+	if err != nil {
+		return
+	}
+
+	for _, target := range targets {
+		if target.Path == "" {
+			logger.Info("Output will be written to stdout", "format", target.Format)
+			continue
+		}
+		if objectstore.IsURI(target.Path) {
+			logger.Info("Output will be uploaded", "object", target.Path, "format", target.Format)
+			continue
+		}
+		logger.Info("Output will be written to file", "file", target.Path, "format", target.Format)
+		WarnIfOverwriting(logger, target.Path, o.File)
+	}
+}
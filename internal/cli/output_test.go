@@ -0,0 +1,144 @@
+package cli_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/objectstore/objectstoretest"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/cli"
+	"mf-statement/internal/domain"
+)
+
+var _ = Describe("OutputOptions", func() {
+	Context("Targets", func() {
+		It("should default to JSON on stdout", func() {
+			targets, err := cli.OutputOptions{}.Targets()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal([]cli.OutputTarget{{Path: "", Format: cli.FormatJSON}}))
+		})
+
+		It("should infer formats from extensions", func() {
+			targets, err := cli.OutputOptions{
+				Outputs: []string{"stmt.json", "stmt.html", "stmt.csv", "stmt.ndjson", "-"},
+			}.Targets()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal([]cli.OutputTarget{
+				{Path: "stmt.json", Format: cli.FormatJSON},
+				{Path: "stmt.html", Format: cli.FormatJSON},
+				{Path: "stmt.csv", Format: cli.FormatCSV},
+				{Path: "stmt.ndjson", Format: cli.FormatNDJSON},
+				{Path: "", Format: cli.FormatJSON},
+			}))
+		})
+
+		It("should render every output through --template unless --format says otherwise", func() {
+			targets, err := cli.OutputOptions{
+				Outputs:      []string{"report.csv", "report.json", "-"},
+				TemplatePath: "report.tmpl",
+			}.Targets()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal([]cli.OutputTarget{
+				{Path: "report.csv", Format: cli.FormatTemplate},
+				{Path: "report.json", Format: cli.FormatTemplate},
+				{Path: "", Format: cli.FormatTemplate},
+			}))
+
+			targets, err = cli.OutputOptions{
+				Outputs:      []string{"report.csv", "report.txt"},
+				Formats:      []string{cli.FormatCSV, cli.FormatTemplate},
+				TemplatePath: "report.tmpl",
+			}.Targets()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets[0].Format).To(Equal(cli.FormatCSV))
+			Expect(targets[1].Format).To(Equal(cli.FormatTemplate))
+		})
+
+		It("should apply a single format to every output", func() {
+			targets, err := cli.OutputOptions{
+				Outputs: []string{"a.txt", "b.txt"},
+				Formats: []string{cli.FormatNDJSON},
+			}.Targets()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets[0].Format).To(Equal(cli.FormatNDJSON))
+			Expect(targets[1].Format).To(Equal(cli.FormatNDJSON))
+		})
+
+		It("should pair formats with outputs positionally", func() {
+			targets, err := cli.OutputOptions{
+				Outputs: []string{"a.out", "b.out"},
+				Formats: []string{cli.FormatJSON, cli.FormatCSV},
+			}.Targets()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets[1]).To(Equal(cli.OutputTarget{Path: "b.out", Format: cli.FormatCSV}))
+		})
+
+		It("should reject several formats or outputs on stdout", func() {
+			_, err := cli.OutputOptions{Formats: []string{cli.FormatJSON, cli.FormatCSV}}.Targets()
+			Expect(err).To(MatchError(ContainSubstring("several formats need one --out per format")))
+
+			_, err = cli.OutputOptions{
+				Outputs: []string{cli.StdoutPath, "stmt.csv", cli.StdoutPath},
+				Formats: []string{cli.FormatJSON, cli.FormatCSV, cli.FormatNDJSON},
+			}.Targets()
+			Expect(err).To(MatchError(ContainSubstring("only one output can be written to stdout")))
+		})
+
+		It("should reject mismatched formats and outputs", func() {
+			_, err := cli.OutputOptions{
+				Outputs: []string{"a.json", "b.json", "c.json"},
+				Formats: []string{cli.FormatJSON, cli.FormatCSV},
+			}.Targets()
+
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+
+		It("should require a template for the template format", func() {
+			_, err := cli.OutputOptions{Formats: []string{cli.FormatTemplate}}.Targets()
+
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
+
+	Context("CreateWriter", func() {
+		It("should return the writer directly for a single output", func() {
+			writer, err := cli.OutputOptions{Outputs: []string{"stmt.csv"}}.CreateWriter()
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := writer.(*output.CSVFileWriter)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should fan out to several outputs", func() {
+			writer, err := cli.OutputOptions{Outputs: []string{"stmt.json", "-"}}.CreateWriter()
+
+			Expect(err).NotTo(HaveOccurred())
+			multi, ok := writer.(*output.MultiWriter)
+			Expect(ok).To(BeTrue())
+			Expect(multi.Destinations).To(HaveLen(2))
+			Expect(multi.Destinations[1].Name).To(Equal("stdout"))
+		})
+
+		It("should upload s3:// outputs in the inferred format", func() {
+			server := objectstoretest.NewServer()
+			defer server.Close()
+
+			writer, err := cli.OutputOptions{Outputs: []string{"s3://statements/2025-01.ndjson"}, S3: server.Config()}.CreateWriter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Write(context.Background(), domain.Statement{Period: "2025/01"})).To(Succeed())
+
+			content, ok := server.Get("statements", "2025-01.ndjson")
+			Expect(ok).To(BeTrue())
+			Expect(string(content)).To(ContainSubstring(`"type":"summary"`))
+			Expect(server.Header("statements", "2025-01.ndjson").Get("Content-Type")).To(Equal("application/x-ndjson"))
+		})
+	})
+})
@@ -29,7 +29,13 @@ calculating income, expenditure, and providing detailed transaction summaries.`,
 
 	root.AddCommand(NewVersionCommand())
 	root.AddCommand(NewGenerateCommand())
+	root.AddCommand(NewImportCommand())
+	root.AddCommand(NewCompareCommand())
+	root.AddCommand(NewFormatsCommand())
+	root.AddCommand(NewConfigCommand())
 	root.AddCommand(generateOptimizedCmd)
+	root.AddCommand(NewServeCommand())
+	root.AddCommand(NewServeGRPCCommand())
 
 	return root
 }
@@ -23,14 +23,14 @@ var _ = Describe("RootCommand", func() {
 		Expect(root.Long).To(ContainSubstring("transaction CSVs"))
 	})
 
-	It("should include the generate and version subcommands", func() {
+	It("should include the generate, import, compare, formats, config and version subcommands", func() {
 		commands := root.Commands()
 		commandNames := make([]string, len(commands))
 		for i, cmd := range commands {
 			commandNames[i] = cmd.Use
 		}
 
-		Expect(commandNames).To(ContainElements("generate", "version"))
+		Expect(commandNames).To(ContainElements("generate", "import", "compare", "formats", "config", "version"))
 	})
 
 	It("should execute help text by default", func(ctx SpecContext) {
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"io"
+	"mime"
+	"os"
+	"path"
+
+	"github.com/spf13/pflag"
+
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+)
+
+// AddS3Flags registers the flags configuring s3:// inputs and outputs. The endpoint and
+// region default to AWS_ENDPOINT_URL_S3 / AWS_ENDPOINT_URL and AWS_REGION.
+func AddS3Flags(flags *pflag.FlagSet, cfg *objectstore.Config) {
+	flags.StringVar(&cfg.Endpoint, "s3-endpoint", firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"), "S3-compatible endpoint URL for s3:// inputs and outputs (default: AWS)")
+	flags.StringVar(&cfg.Region, "s3-region", firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"), "S3 region (default: us-east-1)")
+	flags.BoolVar(&cfg.PathStyle, "s3-path-style", false, "Use path-style bucket addressing, as most self-hosted stores require")
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// createS3Writer uploads the statement rendered in the target format to an s3:// URI
+func (o OutputOptions) createS3Writer(target OutputTarget) (output.Writer, error) {
+	if _, _, err := objectstore.ParseURI(target.Path); err != nil {
+		return nil, domain.NewValidationError("invalid S3 output", map[string]interface{}{
+			"output": target.Path,
+			"error":  err.Error(),
+		})
+	}
+
+	encode, err := o.encoderFor(target.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := objectstore.NewClient(o.S3)
+	if err != nil {
+		return nil, domain.NewValidationError("invalid S3 configuration", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	writer := output.NewS3(client, target.Path, encode)
+	writer.ContentType = contentTypeFor(target)
+	writer.NoClobber = o.File.NoClobber
+	return writer, nil
+}
+
+// encoderFor returns a constructor for the writer of format over the upload buffer
+func (o OutputOptions) encoderFor(format string) (func(io.Writer) output.Writer, error) {
+	if format == FormatTemplate {
+		tmpl, err := parseTemplate(o.TemplatePath)
+		if err != nil {
+			return nil, err
+		}
+		return func(w io.Writer) output.Writer { return output.NewTemplate(w, tmpl) }, nil
+	}
+
+	// Checks the format once; the writers built per upload cannot fail after that
+	if _, err := createFormatWriter(io.Discard, "", format, o.File); err != nil {
+		return nil, err
+	}
+	return func(w io.Writer) output.Writer {
+		writer, _ := createFormatWriter(w, "", format, o.File)
+		return writer
+	}, nil
+}
+
+func contentTypeFor(target OutputTarget) string {
+	switch target.Format {
+	case "", FormatJSON:
+		return "application/json"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatCSV:
+		return "text/csv"
+	}
+	if contentType := mime.TypeByExtension(path.Ext(target.Path)); contentType != "" {
+		return contentType
+	}
+	return "text/plain; charset=utf-8"
+}
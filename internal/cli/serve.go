@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"mf-statement/internal/adapters/in/httpapi"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/util"
+
+	"github.com/spf13/cobra"
+)
+
+func NewServeCommand() *cobra.Command {
+	var (
+		addr            string
+		dataDir         string
+		maxUploadMB     int64
+		requestTimeout  int
+		shutdownTimeout int
+		verbose         bool
+		inputOptions    InputOptions
+		configOptions   ConfigOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve statements over an HTTP REST API",
+		Long: `Starts an HTTP server that generates monthly statements on request.
+
+Endpoints:
+  POST /statements?period=YYYYMM                  Upload an input (multipart "file" field or raw body)
+  GET  /statements?period=YYYYMM&source=file.csv  Read an input from --data-dir
+  GET  /healthz                                   Liveness check
+
+A format parameter selects the input format of a request; without one the
+--input-format default applies, which detects it from the content and file name.
+The --csv-* and other input flags configure the parsers.
+
+Statements are returned as JSON. Validation errors map to 400, unknown sources
+to 404, malformed input to 422 and unexpected failures to 500.`,
+		Example: `  # Accept uploads only
+  mf-statement serve --addr :8080
+
+  # Also serve CSV files stored in ./exports
+  mf-statement serve --addr :8080 --data-dir ./exports
+
+  # Request a statement
+  curl -F period=202501 -F file=@transactions.csv http://localhost:8080/statements`,
+		PreRunE: ApplyConfig(&configOptions, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if verbose {
+				logger = util.NewDebugLogger()
+			}
+
+			if maxUploadMB <= 0 {
+				return domain.NewValidationError("--max-upload-mb must be at least 1", map[string]interface{}{
+					"max_upload_mb": maxUploadMB,
+				})
+			}
+			registry, err := inputOptions.CreateParserRegistry()
+			if err != nil {
+				return err
+			}
+			if _, err := registry.Parser(inputOptions.Format); err != nil {
+				return err
+			}
+
+			server := httpapi.NewServer(dataDir, logger)
+			server.MaxUploadBytes = maxUploadMB << 20
+			server.Parsers = registry
+			server.Format = inputOptions.Format
+
+			handler := http.Handler(server.Handler())
+			if requestTimeout > 0 {
+				handler = http.TimeoutHandler(handler, time.Duration(requestTimeout)*time.Second, `{"error":{"type":"internal","message":"request timed out"}}`)
+			}
+
+			httpServer := &http.Server{
+				Addr:              addr,
+				Handler:           handler,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			errCh := make(chan error, 1)
+			go func() {
+				logger.Info("Serving statements", "addr", addr, "data_dir", dataDir)
+				errCh <- httpServer.ListenAndServe()
+			}()
+
+			select {
+			case err := <-errCh:
+				if !errors.Is(err, http.ErrServerClosed) {
+					return err
+				}
+				return nil
+			case <-ctx.Done():
+			}
+
+			logger.Info("Shutting down server")
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(shutdownTimeout)*time.Second)
+			defer cancel()
+			return httpServer.Shutdown(shutdownCtx)
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", ":8080", "Address to listen on")
+	cmd.Flags().StringVar(&dataDir, "data-dir", "", "Directory GET /statements may read sources from (disabled when empty)")
+	cmd.Flags().Int64Var(&maxUploadMB, "max-upload-mb", httpapi.DefaultMaxUploadBytes>>20, "Maximum size of an upload in MiB, at least 1")
+	cmd.Flags().IntVar(&requestTimeout, "request-timeout", 30, "Timeout in seconds for generating a statement (0 disables)")
+	cmd.Flags().IntVar(&shutdownTimeout, "shutdown-timeout", 10, "Seconds to wait for in-flight requests on shutdown")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
+	AddInputFlags(cmd.Flags(), &inputOptions)
+	AddConfigFlags(cmd.Flags(), &configOptions)
+
+	return cmd
+}
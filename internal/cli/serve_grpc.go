@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"mf-statement/internal/adapters/in/grpcapi"
+	"mf-statement/internal/util"
+
+	"github.com/spf13/cobra"
+)
+
+func NewServeGRPCCommand() *cobra.Command {
+	var (
+		addr          string
+		dataDir       string
+		verbose       bool
+		inputOptions  InputOptions
+		configOptions ConfigOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve-grpc",
+		Short: "Serve statements over gRPC",
+		Long: `Starts a gRPC server implementing statement.v1.StatementService
+(see proto/statement/v1/statement.proto):
+
+  GenerateStatement   Statement of a period
+  StreamTransactions  Transactions of a period streamed in input order, then a summary
+  ValidateCSV         Check that a CSV parses
+
+Input is sent inline or named relative to --data-dir, in any supported format:
+--input-format selects it, by default detected from the content and source name,
+and the --csv-* and other input flags configure the parsers. Server reflection is
+enabled so tools like grpcurl can discover the API.`,
+		Example: `  # Accept inline CSV only
+  mf-statement serve-grpc --addr :9090
+
+  # Also read CSV files stored in ./exports
+  mf-statement serve-grpc --addr :9090 --data-dir ./exports`,
+		PreRunE: ApplyConfig(&configOptions, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if verbose {
+				logger = util.NewDebugLogger()
+			}
+
+			registry, err := inputOptions.CreateParserRegistry()
+			if err != nil {
+				return err
+			}
+			if _, err := registry.Parser(inputOptions.Format); err != nil {
+				return err
+			}
+
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+
+			server := grpcapi.NewServer(dataDir, logger)
+			server.Parsers = registry
+			server.Format = inputOptions.Format
+
+			grpcServer := grpc.NewServer()
+			server.Register(grpcServer)
+			reflection.Register(grpcServer)
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			go func() {
+				<-ctx.Done()
+				logger.Info("Shutting down gRPC server")
+				grpcServer.GracefulStop()
+			}()
+
+			logger.Info("Serving gRPC statements", "addr", listener.Addr().String(), "data_dir", dataDir)
+			return grpcServer.Serve(listener)
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", ":9090", "Address to listen on")
+	cmd.Flags().StringVar(&dataDir, "data-dir", "", "Directory named sources are read from (disabled when empty)")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
+	AddInputFlags(cmd.Flags(), &inputOptions)
+	AddConfigFlags(cmd.Flags(), &configOptions)
+
+	return cmd
+}
@@ -0,0 +1,28 @@
+package cli_test
+
+import (
+	. "mf-statement/internal/cli"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServeCommand", func() {
+	BeforeEach(func() {
+		NewRootCommand() // This initializes the global logger
+	})
+
+	It("should reject a --max-upload-mb below 1", func() {
+		cmd := NewServeCommand()
+		cmd.SetArgs([]string{"--addr", "127.0.0.1:0", "--max-upload-mb", "0"})
+
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring("--max-upload-mb must be at least 1")))
+	})
+
+	It("should reject an unknown --input-format before listening", func() {
+		cmd := NewServeCommand()
+		cmd.SetArgs([]string{"--addr", "127.0.0.1:0", "--input-format", "pdf"})
+
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring("unsupported input format")))
+	})
+})
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"mf-statement/internal/adapters/in"
+	"mf-statement/internal/adapters/objectstore"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// SourceOptions collects the input flags shared by the generate commands
+type SourceOptions struct {
+	Headers    []string
+	Retries    int
+	MaxInputMB int64
+	S3         objectstore.Config
+}
+
+// AddSourceFlags registers the flags configuring remote CSV sources
+func AddSourceFlags(flags *pflag.FlagSet, options *SourceOptions) {
+	flags.StringArrayVar(&options.Headers, "header", nil, `HTTP header sent when --csv is an http(s) URL, e.g. "Authorization: Bearer TOKEN"; repeatable`)
+	flags.IntVar(&options.Retries, "http-retries", in.DefaultHTTPMaxRetries, "Retries for failed http(s) downloads")
+	flags.Int64Var(&options.MaxInputMB, "max-input-mb", in.DefaultHTTPMaxBytes>>20, "Maximum size of an http(s) download in MiB, 0 for no limit")
+}
+
+// ParseHeaders parses "Name: value" pairs into an http.Header
+func ParseHeaders(headers []string) (http.Header, error) {
+	parsed := http.Header{}
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, domain.NewValidationError("header must be in \"Name: value\" format", map[string]interface{}{
+				"header": header,
+			})
+		}
+		parsed.Add(name, strings.TrimSpace(value))
+	}
+	return parsed, nil
+}
+
+// CreateSource builds the source resolving --csv paths, stdin, http(s) URLs and s3:// URIs,
+// decompressing compressed inputs on the fly
+func (o SourceOptions) CreateSource() (usecase.Source, error) {
+	headers, err := ParseHeaders(o.Headers)
+	if err != nil {
+		return nil, err
+	}
+	if o.Retries < 0 || o.MaxInputMB < 0 {
+		return nil, domain.NewValidationError("http retries and input size must not be negative", map[string]interface{}{
+			"http_retries": o.Retries,
+			"max_input_mb": o.MaxInputMB,
+		})
+	}
+
+	httpSource := in.NewHTTPSource()
+	httpSource.Headers = headers
+	httpSource.MaxRetries = o.Retries
+	httpSource.MaxBytes = o.MaxInputMB << 20
+	registry := in.NewDefaultSourceRegistry(httpSource)
+
+	registry.Register(objectstore.Scheme, in.NewLazyS3Source(o.S3))
+	return in.NewDecompressingSource(registry), nil
+}
+
+// InputArgs accepts positional inputs only when they are read like --csv values;
+// --db and --store read no input files, so arguments would be silently ignored
+func InputArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	for _, name := range []string{"db", "store"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return domain.NewValidationError(fmt.Sprintf("input arguments cannot be combined with --%s", name), map[string]interface{}{
+				"args": args,
+			})
+		}
+	}
+	return nil
+}
+
+// QueryURI is the URI the service of createTransactionService is queried with: the
+// only input, or none when the service merges several inputs or reads a database or store
+func QueryURI(inputs []string) string {
+	if len(inputs) == 1 {
+		return inputs[0]
+	}
+	return ""
+}
+
+// ExpandInputs expands glob patterns and directories in local --csv values, including
+// file:// URIs, and drops repeated inputs. Patterns are expanded in lexical order and
+// must match at least one file.
+func ExpandInputs(inputs []string) ([]string, error) {
+	var expanded []string
+	seen := map[string]bool{}
+	add := func(input string) {
+		if !seen[input] {
+			seen[input] = true
+			expanded = append(expanded, input)
+		}
+	}
+
+	for _, input := range inputs {
+		if in.Scheme(input) != "file" {
+			add(input)
+			continue
+		}
+		path := in.LocalPath(input)
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			files, err := directoryInputs(path)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range files {
+				add(file)
+			}
+			continue
+		}
+		if !strings.ContainsAny(path, "*?[") {
+			add(input)
+			continue
+		}
+
+		matches, err := filepath.Glob(path)
+		if err != nil || len(matches) == 0 {
+			return nil, domain.NewValidationError("input pattern matches no files", map[string]interface{}{
+				"pattern": input,
+			})
+		}
+		for _, match := range matches {
+			add(match)
+		}
+	}
+	return expanded, nil
+}
+
+// directoryInputs lists the files of a directory input in name order, leaving out
+// subdirectories and hidden files such as partial downloads
+func directoryInputs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, domain.NewIOError("failed to list input directory", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return nil, domain.NewValidationError("input directory contains no files", map[string]interface{}{
+			"directory": dir,
+		})
+	}
+	return files, nil
+}
@@ -2,10 +2,22 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"text/template"
 
 	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/util"
+)
+
+// Supported output formats
+const (
+	FormatJSON     = "json"
+	FormatNDJSON   = "ndjson"
+	FormatCSV      = "csv"
+	FormatTemplate = "template"
 )
 
 // ParsePeriod parses a period string in YYYYMM format
@@ -39,3 +51,90 @@ func CreateWriter(outputPath string) output.Writer {
 	}
 	return output.NewJSONFile(outputPath)
 }
+
+// ParseFileMode parses an octal permission string such as "0600"
+func ParseFileMode(mode string) (os.FileMode, error) {
+	if mode == "" {
+		return output.DefaultFilePerm, nil
+	}
+
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || perm > 0777 {
+		return 0, domain.NewValidationError("invalid file mode", map[string]interface{}{
+			"mode": mode,
+		})
+	}
+	return os.FileMode(perm), nil
+}
+
+// CreateFormatWriter creates a writer for the given output format
+func CreateFormatWriter(outputPath, format string, options output.FileOptions) (output.Writer, error) {
+	return createFormatWriter(os.Stdout, outputPath, format, options)
+}
+
+// createFormatWriter writes to stream when outputPath is empty
+func createFormatWriter(stream io.Writer, outputPath, format string, options output.FileOptions) (output.Writer, error) {
+	switch format {
+	case "", FormatJSON:
+		if outputPath == "" {
+			return output.NewJSON(stream), nil
+		}
+		writer := output.NewJSONFile(outputPath)
+		writer.Options = options
+		return writer, nil
+	case FormatNDJSON:
+		if outputPath == "" {
+			return output.NewNDJSON(stream), nil
+		}
+		writer := output.NewNDJSONFile(outputPath)
+		writer.Options = options
+		return writer, nil
+	case FormatCSV:
+		if outputPath == "" {
+			return output.NewCSV(stream), nil
+		}
+		writer := output.NewCSVFile(outputPath)
+		writer.Options = options
+		return writer, nil
+	default:
+		return nil, domain.NewValidationError("unsupported output format", map[string]interface{}{
+			"format": format,
+		})
+	}
+}
+
+// CreateTemplateWriter creates a writer that renders statements through the template at templatePath
+func CreateTemplateWriter(outputPath, templatePath string, options output.FileOptions) (output.Writer, error) {
+	tmpl, err := parseTemplate(templatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if outputPath == "" {
+		return output.NewTemplate(os.Stdout, tmpl), nil
+	}
+	writer := output.NewTemplateFile(outputPath, tmpl)
+	writer.Options = options
+	return writer, nil
+}
+
+func parseTemplate(templatePath string) (*template.Template, error) {
+	tmpl, err := output.ParseTemplateFile(templatePath)
+	if err != nil {
+		return nil, domain.NewValidationError("invalid template", map[string]interface{}{
+			"template": templatePath,
+			"error":    err.Error(),
+		})
+	}
+	return tmpl, nil
+}
+
+// WarnIfOverwriting logs a warning when outputPath already exists and will be replaced
+func WarnIfOverwriting(logger *util.Logger, outputPath string, options output.FileOptions) {
+	if outputPath == "" || options.NoClobber {
+		return
+	}
+	if _, err := os.Stat(outputPath); err == nil {
+		logger.Warn("Overwriting existing output file (use --no-clobber to refuse)", "file", outputPath)
+	}
+}
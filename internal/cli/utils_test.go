@@ -7,8 +7,11 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"mf-statement/internal/adapters/in/database"
 	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/adapters/out/parser"
 	"mf-statement/internal/cli"
+	"mf-statement/internal/domain"
 )
 
 var _ = Describe("CLI Utils", func() {
@@ -61,4 +64,194 @@ var _ = Describe("CLI Utils", func() {
 			Expect(ok).To(BeTrue())
 		})
 	})
+
+	Context("ParseFileMode", func() {
+		It("should parse octal modes", func() {
+			mode, err := cli.ParseFileMode("0600")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mode).To(Equal(os.FileMode(0600)))
+		})
+
+		It("should reject invalid modes", func() {
+			_, err := cli.ParseFileMode("999")
+
+			Expect(err).To(HaveOccurred())
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
+
+	Context("ParseHeaders", func() {
+		It("should parse name and value pairs", func() {
+			headers, err := cli.ParseHeaders([]string{"Authorization: Bearer a:b", "X-Trace:1"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers.Get("Authorization")).To(Equal("Bearer a:b"))
+			Expect(headers.Get("X-Trace")).To(Equal("1"))
+		})
+
+		It("should reject headers without a name", func() {
+			_, err := cli.ParseHeaders([]string{"Bearer token"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
+
+	Context("ExpandInputs", func() {
+		It("should expand globs in order and drop repeated inputs", func() {
+			dir := GinkgoT().TempDir()
+			for _, name := range []string{"2025-02.csv", "2025-01.csv", "notes.txt"} {
+				Expect(os.WriteFile(filepath.Join(dir, name), nil, 0644)).To(Succeed())
+			}
+
+			inputs, err := cli.ExpandInputs([]string{filepath.Join(dir, "2025-*.csv"), filepath.Join(dir, "2025-01.csv"), "-", "https://example.com/a*.csv"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(Equal([]string{filepath.Join(dir, "2025-01.csv"), filepath.Join(dir, "2025-02.csv"), "-", "https://example.com/a*.csv"}))
+		})
+
+		It("should expand directories into their files in name order", func() {
+			dir := GinkgoT().TempDir()
+			for _, name := range []string{"b.csv", "a.csv", ".partial.csv"} {
+				Expect(os.WriteFile(filepath.Join(dir, name), nil, 0644)).To(Succeed())
+			}
+			Expect(os.Mkdir(filepath.Join(dir, "archive"), 0755)).To(Succeed())
+
+			inputs, err := cli.ExpandInputs([]string{dir})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(Equal([]string{filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")}))
+		})
+
+		It("should expand file:// globs and directories", func() {
+			dir := GinkgoT().TempDir()
+			for _, name := range []string{"2025-02.csv", "2025-01.csv"} {
+				Expect(os.WriteFile(filepath.Join(dir, name), nil, 0644)).To(Succeed())
+			}
+
+			inputs, err := cli.ExpandInputs([]string{"file://" + filepath.Join(dir, "*-01.csv"), "file://" + dir})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(Equal([]string{filepath.Join(dir, "2025-01.csv"), filepath.Join(dir, "2025-02.csv")}))
+		})
+
+		It("should reject patterns without matches", func() {
+			_, err := cli.ExpandInputs([]string{filepath.Join(GinkgoT().TempDir(), "*.csv")})
+
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
+
+	Context("ParseColumns", func() {
+		It("should override the default mapping", func() {
+			columns, err := cli.ParseColumns("date=posted_on, category=tag")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(columns).To(Equal(database.Columns{Date: "posted_on", Amount: "amount", Content: "content", Category: "tag"}))
+		})
+
+		It("should reject unknown fields", func() {
+			_, err := cli.ParseColumns("balance=total")
+
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
+
+	Context("ParseJSONFields", func() {
+		It("should override the default paths", func() {
+			fields, err := cli.ParseJSONFields("date=posted_at, amount=amount.value")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fields).To(Equal(parser.JSONFields{Date: "posted_at", Amount: "amount.value", Content: "content", Category: "category", ID: "id"}))
+		})
+
+		It("should reject pairs without a path", func() {
+			_, err := cli.ParseJSONFields("date=")
+
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
+
+	Context("CSVInputOptions", func() {
+		It("should build the dialect from the flags", func() {
+			dialect, err := cli.CSVInputOptions{Delimiter: "tab", Comment: "#", SkipLines: 3, SkipTrailing: 1}.Dialect()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dialect).To(Equal(parser.CSVDialect{Comma: '\t', Comment: '#', SkipLines: 3, SkipTrailing: 1}))
+		})
+
+		It("should reject delimiters longer than one character", func() {
+			_, err := cli.CSVInputOptions{Delimiter: ";;"}.Dialect()
+
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
+
+	Context("ParseXLSXColumns", func() {
+		It("should override the default header names", func() {
+			columns, err := cli.ParseXLSXColumns("date=Booking Date, content=Description")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(columns).To(Equal(parser.XLSXColumns{Date: "Booking Date", Amount: "amount", Content: "Description", Category: "category"}))
+		})
+
+		It("should reject unknown fields", func() {
+			_, err := cli.ParseXLSXColumns("id=Reference")
+
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
+
+	Context("CreateFormatWriter", func() {
+		It("should create NDJSON writers", func() {
+			writer, err := cli.CreateFormatWriter("", cli.FormatNDJSON, output.FileOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := writer.(*output.NDJSONWriter)
+			Expect(ok).To(BeTrue())
+
+			writer, err = cli.CreateFormatWriter("statement.ndjson", cli.FormatNDJSON, output.FileOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok = writer.(*output.NDJSONFileWriter)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should reject unknown formats", func() {
+			_, err := cli.CreateFormatWriter("", "xml", output.FileOptions{})
+
+			Expect(err).To(HaveOccurred())
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
+
+	Context("CreateTemplateWriter", func() {
+		It("should create template file writer for file path", func() {
+			tempDir, err := os.MkdirTemp("", "cli_test_*")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+
+			tmplPath := filepath.Join(tempDir, "statement.tmpl")
+			Expect(os.WriteFile(tmplPath, []byte(`{{ .Period }}`), 0644)).To(Succeed())
+
+			writer, err := cli.CreateTemplateWriter(filepath.Join(tempDir, "out.txt"), tmplPath, output.FileOptions{})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := writer.(*output.TemplateFileWriter)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should return validation error for invalid template", func() {
+			tempDir, err := os.MkdirTemp("", "cli_test_*")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+
+			tmplPath := filepath.Join(tempDir, "statement.tmpl")
+			Expect(os.WriteFile(tmplPath, []byte(`{{ .Period `), 0644)).To(Succeed())
+
+			_, err = cli.CreateTemplateWriter("", tmplPath, output.FileOptions{})
+
+			Expect(err).To(HaveOccurred())
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+		})
+	})
 })
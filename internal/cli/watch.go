@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"mf-statement/internal/adapters/in"
+	"mf-statement/internal/domain"
+)
+
+// WatchOptions collects the flags of generate --watch
+type WatchOptions struct {
+	Enabled  bool
+	Debounce time.Duration
+	Poll     time.Duration
+}
+
+// AddWatchFlags registers the flags regenerating statements when inputs change
+func AddWatchFlags(flags *pflag.FlagSet, options *WatchOptions) {
+	flags.BoolVarP(&options.Enabled, "watch", "w", false, "Keep running and regenerate the output whenever the --csv files, globs or directories change")
+	flags.DurationVar(&options.Debounce, "watch-debounce", in.DefaultWatchDebounce, "Quiet period after the last change before regenerating")
+	flags.DurationVar(&options.Poll, "watch-poll", 0, "Poll for changes at this interval instead of using file notifications, e.g. on network file systems (default: notifications, polling every 2s where unavailable)")
+}
+
+// Run generates once and then again after every settled change of the inputs,
+// until ctx is done or the process is interrupted. Failed runs are logged, since
+// an export job may still be writing the input. outDir is the directory of
+// --all-periods statements, if any.
+func (o WatchOptions) Run(ctx context.Context, inputs, outputs []string, outDir string, generate func(ctx context.Context) error) error {
+	paths, err := watchPaths(inputs, outputs, outDir)
+	if err != nil {
+		return err
+	}
+	if o.Debounce < 0 || o.Poll < 0 {
+		return domain.NewValidationError("watch intervals must not be negative", map[string]interface{}{
+			"watch_debounce": o.Debounce.String(),
+			"watch_poll":     o.Poll.String(),
+		})
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := in.NewFileWatcher(paths)
+	watcher.Debounce = o.Debounce
+	if o.Poll > 0 {
+		watcher.Poll, watcher.PollInterval = true, o.Poll
+	}
+	watcher.OnFallback = func(err error) {
+		logger.Warn("File notifications unavailable, polling for changes", "error", err, "interval", watcher.PollInterval)
+	}
+
+	regenerate := func(ctx context.Context) error {
+		if err := generate(ctx); err != nil {
+			logger.Error("Failed to regenerate statement", "error", err)
+		}
+		return nil
+	}
+
+	_ = regenerate(ctx)
+	logger.Info("Watching inputs for changes", "paths", paths)
+	return watcher.Watch(ctx, func(ctx context.Context) error {
+		logger.Info("Inputs changed, regenerating")
+		return regenerate(ctx)
+	})
+}
+
+// watchPaths returns the local paths of the inputs, rejecting inputs that cannot be
+// watched and outputs that would trigger their own regeneration
+func watchPaths(inputs, outputs []string, outDir string) ([]string, error) {
+	var paths []string
+	for _, input := range inputs {
+		if in.Scheme(input) != "file" {
+			return nil, domain.NewValidationError("--watch needs local --csv files or directories", map[string]interface{}{
+				"input": input,
+			})
+		}
+		path, err := filepath.Abs(strings.TrimPrefix(input, "file://"))
+		if err != nil {
+			return nil, domain.NewValidationError("invalid watch path", map[string]interface{}{"input": input})
+		}
+		paths = append(paths, path)
+	}
+
+	for _, out := range outputs {
+		if out == "" || out == StdoutPath || in.Scheme(out) != "file" {
+			continue
+		}
+		outPath, err := filepath.Abs(out)
+		if err != nil {
+			continue
+		}
+		for _, path := range paths {
+			matched, _ := filepath.Match(path, outPath)
+			if matched || outPath == path || (isDirectory(path) && filepath.Dir(outPath) == path) {
+				return nil, domain.NewValidationError("output is among the watched inputs and would regenerate itself", map[string]interface{}{
+					"output": out,
+					"input":  path,
+				})
+			}
+		}
+	}
+
+	if outDir != "" && in.Scheme(outDir) == "file" {
+		dir, err := filepath.Abs(in.LocalPath(outDir))
+		if err != nil {
+			return nil, domain.NewValidationError("invalid output directory", map[string]interface{}{"out_dir": outDir})
+		}
+		for _, path := range paths {
+			// A watched directory or pattern sees every file created in its directory,
+			// and statements may be written anywhere below the output directory
+			watchedDir := path
+			if strings.ContainsAny(path, "*?[") {
+				watchedDir = filepath.Dir(path)
+			} else if !isDirectory(path) {
+				continue
+			}
+			if within(dir, watchedDir) || within(watchedDir, dir) {
+				return nil, domain.NewValidationError("--out-dir overlaps the watched inputs and would regenerate itself", map[string]interface{}{
+					"out_dir": outDir,
+					"input":   path,
+				})
+			}
+		}
+	}
+	return paths, nil
+}
+
+// within reports whether path is dir or lies below it
+func within(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && (rel == "." || filepath.IsLocal(rel))
+}
+
+func isDirectory(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
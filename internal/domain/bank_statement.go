@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Balance is an account balance reported by the bank, in minor units
+type Balance struct {
+	Date   time.Time
+	Amount int64
+}
+
+// BankStatement is an account statement as issued by a bank, such as a camt.053
+// or MT940 statement, with the balances it reports around its transactions
+type BankStatement struct {
+	ID           string
+	Account      string
+	Currency     string
+	Opening      *Balance
+	Closing      *Balance
+	Transactions []Transaction
+}
+
+// Reconcile checks that the opening balance plus the transactions gives the closing
+// balance. Statements that do not report both balances cannot be checked and pass.
+func (s BankStatement) Reconcile() error {
+	if s.Opening == nil || s.Closing == nil {
+		return nil
+	}
+
+	computed := s.Opening.Amount
+	for _, tx := range s.Transactions {
+		computed += tx.Amount
+	}
+	if computed != s.Closing.Amount {
+		return NewValidationError(fmt.Sprintf("statement %s does not reconcile: opening balance %d plus transactions gives %d, bank reports closing balance %d",
+			s.ID, s.Opening.Amount, computed, s.Closing.Amount), map[string]interface{}{
+			"statement":  s.ID,
+			"account":    s.Account,
+			"opening":    s.Opening.Amount,
+			"computed":   computed,
+			"closing":    s.Closing.Amount,
+			"difference": s.Closing.Amount - computed,
+		})
+	}
+	return nil
+}
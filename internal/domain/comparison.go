@@ -0,0 +1,41 @@
+package domain
+
+import "math"
+
+// Change compares a figure of two periods. PercentChange is relative to the magnitude
+// of the previous value and nil when there is none; expenditure is negative, so more
+// spending shows as a negative delta and percentage.
+type Change struct {
+	Current       int64    `json:"current"`
+	Previous      int64    `json:"previous"`
+	Delta         int64    `json:"delta"`
+	PercentChange *float64 `json:"percent_change"`
+}
+
+func NewChange(current, previous int64) Change {
+	change := Change{Current: current, Previous: previous, Delta: current - previous}
+	if previous != 0 {
+		percent := math.Round(float64(change.Delta)/math.Abs(float64(previous))*10000) / 100
+		change.PercentChange = &percent
+	}
+	return change
+}
+
+// CategoryChange compares the spend of one category
+type CategoryChange struct {
+	Category string `json:"category"`
+	Change
+}
+
+// Comparison reports how a period's figures changed against a comparison period
+type Comparison struct {
+	Period           string           `json:"period"`
+	ComparedTo       string           `json:"compared_to"`
+	Income           Change           `json:"income"`
+	Expenditure      Change           `json:"expenditure"`
+	Net              Change           `json:"net"`
+	TransactionCount Change           `json:"transaction_count"`
+	IncomeCount      Change           `json:"income_count"`
+	ExpenseCount     Change           `json:"expense_count"`
+	Categories       []CategoryChange `json:"categories,omitempty"`
+}
@@ -79,6 +79,30 @@ var _ = Describe("Domain", func() {
 		})
 	})
 
+	Context("BankStatement", func() {
+		It("should reconcile balances with transactions", func() {
+			statement := domain.BankStatement{
+				ID:           "STMT-1",
+				Opening:      &domain.Balance{Amount: 1000},
+				Closing:      &domain.Balance{Amount: 1800},
+				Transactions: []domain.Transaction{{Amount: 1000}, {Amount: -200}},
+			}
+
+			Expect(statement.Reconcile()).To(Succeed())
+
+			statement.Closing.Amount = 1700
+			err := statement.Reconcile()
+			Expect(domain.IsValidationError(err)).To(BeTrue())
+			Expect(err.(domain.DomainError).Details["difference"]).To(Equal(int64(-100)))
+		})
+
+		It("should pass statements without both balances", func() {
+			statement := domain.BankStatement{Opening: &domain.Balance{Amount: 1000}, Transactions: []domain.Transaction{{Amount: 5}}}
+
+			Expect(statement.Reconcile()).To(Succeed())
+		})
+	})
+
 	Context("DomainError", func() {
 		It("should create validation error", func() {
 			err := domain.NewValidationError("test error", map[string]interface{}{"field": "value"})
@@ -182,4 +206,21 @@ var _ = Describe("Domain", func() {
 			Expect(errorStr).To(ContainSubstring("single error"))
 		})
 	})
+
+	Describe("Change", func() {
+		It("should compute the delta and the percentage relative to the earlier magnitude", func() {
+			change := domain.NewChange(-300, -200)
+
+			Expect(change.Delta).To(Equal(int64(-100)))
+			Expect(*change.PercentChange).To(Equal(-50.0))
+			Expect(*domain.NewChange(1000, 3000).PercentChange).To(Equal(-66.67))
+		})
+
+		It("should leave the percentage out when the earlier value is zero", func() {
+			change := domain.NewChange(500, 0)
+
+			Expect(change.Delta).To(Equal(int64(500)))
+			Expect(change.PercentChange).To(BeNil())
+		})
+	})
 })
@@ -1,30 +1,36 @@
 package domain
 
-import (
-	"fmt"
-)
-
 type Statement struct {
 	Period           string           `json:"period"`
 	TotalIncome      int64            `json:"total_income"`
 	TotalExpenditure int64            `json:"total_expenditure"`
 	Transactions     []TransactionDTO `json:"transactions"`
+	// Duplicates lists transactions left out of the totals because another input already had them
+	Duplicates []TransactionDTO `json:"duplicates,omitempty"`
 }
 
 func NewStatement(period string, transactions []Transaction, totalIncome, totalExpenditure int64) Statement {
-	transactionDTOs := make([]TransactionDTO, len(transactions))
-	for i, tx := range transactions {
-		transactionDTOs[i] = TransactionDTO{
-			Date:    tx.Date.Format(CSVDateLayout),
-			Amount:  fmt.Sprintf("%d", tx.Amount),
-			Content: tx.Content,
-		}
-	}
-
 	return Statement{
 		Period:           period,
 		TotalIncome:      totalIncome,
 		TotalExpenditure: totalExpenditure,
-		Transactions:     transactionDTOs,
+		Transactions:     NewTransactionDTOs(transactions),
+	}
+}
+
+// Summary holds the aggregate figures of a statement without its transactions
+type Summary struct {
+	Period           string `json:"period"`
+	TotalIncome      int64  `json:"total_income"`
+	TotalExpenditure int64  `json:"total_expenditure"`
+	TransactionCount int    `json:"transaction_count"`
+}
+
+func (s Statement) Summary() Summary {
+	return Summary{
+		Period:           s.Period,
+		TotalIncome:      s.TotalIncome,
+		TotalExpenditure: s.TotalExpenditure,
+		TransactionCount: len(s.Transactions),
 	}
 }
@@ -8,16 +8,27 @@ import (
 
 const CSVDateLayout = "2006/01/02"
 
+// UncategorizedLabel groups the transactions without a category in per-category totals
+const UncategorizedLabel = "Uncategorized"
+
 type Transaction struct {
-	Date    time.Time
-	Amount  int64
-	Content string
+	Date     time.Time
+	Amount   int64
+	Content  string
+	Category string
+	// ID is the bank-assigned identifier, such as the OFX FITID, when the input has one
+	ID string
+	// Source is the input the transaction was read from when several inputs are merged
+	Source string
 }
 
 type TransactionDTO struct {
-	Date    string `json:"date"`
-	Amount  string `json:"amount"`
-	Content string `json:"content"`
+	Date     string `json:"date"`
+	Amount   string `json:"amount"`
+	Content  string `json:"content"`
+	Category string `json:"category,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Source   string `json:"source,omitempty"`
 }
 
 func NewTransaction(date time.Time, amount int64, content string) (Transaction, error) {
@@ -37,6 +48,25 @@ func NewTransaction(date time.Time, amount int64, content string) (Transaction,
 	}, nil
 }
 
+func NewTransactionDTO(tx Transaction) TransactionDTO {
+	return TransactionDTO{
+		Date:     tx.Date.Format(CSVDateLayout),
+		Amount:   fmt.Sprintf("%d", tx.Amount),
+		Content:  tx.Content,
+		Category: tx.Category,
+		ID:       tx.ID,
+		Source:   tx.Source,
+	}
+}
+
+func NewTransactionDTOs(transactions []Transaction) []TransactionDTO {
+	dtos := make([]TransactionDTO, len(transactions))
+	for i, tx := range transactions {
+		dtos[i] = NewTransactionDTO(tx)
+	}
+	return dtos
+}
+
 func (t Transaction) IsIncome() bool {
 	return t.Amount > 0
 }
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+	"sort"
+)
+
+// Period identifies a statement month
+type Period struct {
+	Year  int
+	Month int
+}
+
+// Display formats the period the way statements show it (2025/01)
+func (p Period) Display() string {
+	return fmt.Sprintf("%d/%02d", p.Year, p.Month)
+}
+
+// Before reports whether p is an earlier month than other
+func (p Period) Before(other Period) bool {
+	if p.Year != other.Year {
+		return p.Year < other.Year
+	}
+	return p.Month < other.Month
+}
+
+// WriterFactory returns the writer receiving the statement of a period
+type WriterFactory func(period Period) (output.Writer, error)
+
+// BatchStatementService writes one statement per month found in a source
+type BatchStatementService struct {
+	TransactionService TransactionService
+	WriterFactory      WriterFactory
+	// Deduplicator is optional; nil keeps every transaction
+	Deduplicator *Deduplicator
+}
+
+func NewBatchStatementService(transactionService TransactionService, writerFactory WriterFactory) *BatchStatementService {
+	return &BatchStatementService{
+		TransactionService: transactionService,
+		WriterFactory:      writerFactory,
+	}
+}
+
+// GenerateAllMonthlyStatements reads the source once, groups its transactions by month and
+// writes a statement for every month present, oldest first. It returns the periods written.
+func (s *BatchStatementService) GenerateAllMonthlyStatements(ctx context.Context, csvFileURI string) ([]Period, error) {
+	allTransactions, err := s.TransactionService.GetAllTransactions(ctx, csvFileURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Deduplicator != nil && s.Deduplicator.Mode == DedupeFail {
+		// Check the whole input before any statement is written
+		if _, _, err := s.Deduplicator.Dedupe(allTransactions); err != nil {
+			return nil, err
+		}
+	}
+
+	grouped := GroupByPeriod(allTransactions)
+
+	periods := make([]Period, 0, len(grouped))
+	for period := range grouped {
+		periods = append(periods, period)
+	}
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Before(periods[j])
+	})
+
+	for i, period := range periods {
+		if err := ctx.Err(); err != nil {
+			return periods[:i], err
+		}
+
+		transactions := grouped[period]
+		sortNewestFirst(transactions)
+
+		statement, err := BuildStatement(s.TransactionService, s.Deduplicator, period.Display(), transactions)
+		if err != nil {
+			return periods[:i], err
+		}
+
+		writer, err := s.WriterFactory(period)
+		if err != nil {
+			return periods[:i], err
+		}
+		if err := writer.Write(ctx, statement); err != nil {
+			return periods[:i], domain.NewIOError(fmt.Sprintf("failed to write statement for %s", period.Display()), err)
+		}
+	}
+
+	return periods, nil
+}
+
+// GroupByPeriod buckets transactions by the month of their date
+func GroupByPeriod(transactions []domain.Transaction) map[Period][]domain.Transaction {
+	grouped := make(map[Period][]domain.Transaction)
+	for _, transaction := range transactions {
+		period := Period{Year: transaction.Date.Year(), Month: int(transaction.Date.Month())}
+		grouped[period] = append(grouped[period], transaction)
+	}
+	return grouped
+}
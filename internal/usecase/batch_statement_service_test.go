@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+var _ = Describe("BatchStatementService", func() {
+	var (
+		ctx                context.Context
+		transactionService *mockTransactionService
+		writers            map[usecase.Period]*mockWriter
+		factory            usecase.WriterFactory
+	)
+
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		transactionService = &mockTransactionService{
+			allTransactions: []domain.Transaction{
+				{Date: date(2025, 2, 3), Amount: 3000, Content: "February Salary"},
+				{Date: date(2025, 1, 5), Amount: 2000, Content: "January Salary"},
+				{Date: date(2024, 12, 24), Amount: -800, Content: "Gift"},
+				{Date: date(2025, 1, 9), Amount: -300, Content: "Grocery"},
+			},
+		}
+		writers = make(map[usecase.Period]*mockWriter)
+		factory = func(period usecase.Period) (output.Writer, error) {
+			writers[period] = &mockWriter{}
+			return writers[period], nil
+		}
+	})
+
+	It("should write one statement per month, oldest first", func() {
+		service := usecase.NewBatchStatementService(transactionService, factory)
+
+		periods, err := service.GenerateAllMonthlyStatements(ctx, "transactions.csv")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(periods).To(Equal([]usecase.Period{{Year: 2024, Month: 12}, {Year: 2025, Month: 1}, {Year: 2025, Month: 2}}))
+
+		january := writers[usecase.Period{Year: 2025, Month: 1}].writtenStatement
+		Expect(january.Period).To(Equal("2025/01"))
+		Expect(january.TotalIncome).To(Equal(int64(2000)))
+		Expect(january.TotalExpenditure).To(Equal(int64(-300)))
+		Expect(january.Transactions[0].Content).To(Equal("Grocery"))
+
+		december := writers[usecase.Period{Year: 2024, Month: 12}].writtenStatement
+		Expect(december.Transactions).To(HaveLen(1))
+	})
+
+	It("should return the source error", func() {
+		transactionService.allTransactionsError = domain.NewIOError("failed to open CSV source", errors.New("missing"))
+		service := usecase.NewBatchStatementService(transactionService, factory)
+
+		_, err := service.GenerateAllMonthlyStatements(ctx, "transactions.csv")
+
+		Expect(domain.IsIOError(err)).To(BeTrue())
+	})
+
+	It("should stop at the first failing write and report written periods", func() {
+		factory = func(period usecase.Period) (output.Writer, error) {
+			if period.Month == 1 {
+				return &mockWriter{writeError: errors.New("disk full")}, nil
+			}
+			return &mockWriter{}, nil
+		}
+		service := usecase.NewBatchStatementService(transactionService, factory)
+
+		periods, err := service.GenerateAllMonthlyStatements(ctx, "transactions.csv")
+
+		Expect(domain.IsIOError(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("2025/01"))
+		Expect(periods).To(Equal([]usecase.Period{{Year: 2024, Month: 12}}))
+	})
+
+	Context("Period", func() {
+		It("should format and order periods", func() {
+			Expect(usecase.Period{Year: 2025, Month: 3}.Display()).To(Equal("2025/03"))
+			Expect(usecase.Period{Year: 2024, Month: 12}.Before(usecase.Period{Year: 2025, Month: 1})).To(BeTrue())
+			Expect(usecase.Period{Year: 2025, Month: 2}.Before(usecase.Period{Year: 2025, Month: 1})).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/util"
+	"sort"
+	"time"
+)
+
+// TransactionIndex keeps the parsed transactions of inputs grouped by month, so that
+// the transactions of a few months can be read back without parsing the input again
+type TransactionIndex interface {
+	// Lookup returns the indexed transactions of the months from start through end in
+	// input order; zero times leave that end open. ok is false when uri has no index
+	// or the input changed since it was built.
+	Lookup(ctx context.Context, uri, variant string, start, end time.Time) (transactions []domain.Transaction, ok bool, err error)
+	// Snapshot records the state of an input before it is parsed; nil means the input
+	// cannot be indexed
+	Snapshot(ctx context.Context, uri string) (*InputSnapshot, error)
+	// Build indexes the transactions parsed after snapshot was taken under that state,
+	// so an input that changed during the parse misses on the next lookup
+	Build(ctx context.Context, uri, variant string, snapshot *InputSnapshot, transactions []domain.Transaction) error
+}
+
+// InputSnapshot identifies the content of an input at a point in time
+type InputSnapshot struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+	SHA256  [32]byte
+}
+
+// CachedTransactionService answers from a TransactionIndex and parses through Service
+// only when the index of an input is missing or stale, building it on the way.
+// Variant identifies the parser configuration, since the same input parsed with other
+// options yields other transactions. Index failures never fail a query: they are
+// passed to OnError and the input is parsed instead.
+type CachedTransactionService struct {
+	Service   TransactionService
+	Index     TransactionIndex
+	Variant   string
+	Validator Validator
+	OnError   func(uri string, err error)
+}
+
+func NewCachedTransactionService(service TransactionService, index TransactionIndex, variant string) *CachedTransactionService {
+	return &CachedTransactionService{
+		Service:   service,
+		Index:     index,
+		Variant:   variant,
+		Validator: NewPeriodValidator(),
+	}
+}
+
+func (s *CachedTransactionService) GetAllTransactions(ctx context.Context, csvFileURI string) ([]domain.Transaction, error) {
+	return s.lookup(ctx, csvFileURI, time.Time{}, time.Time{})
+}
+
+func (s *CachedTransactionService) GetTransactionsByPeriod(ctx context.Context, csvFileURI string, year, month int) ([]domain.Transaction, error) {
+	if err := s.Validator.ValidatePeriod(year, month); err != nil {
+		return nil, err
+	}
+
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	transactions, err := s.lookup(ctx, csvFileURI, start, start)
+	if err != nil {
+		return nil, err
+	}
+	return FilterByPeriod(transactions, year, month), nil
+}
+
+func (s *CachedTransactionService) GetTransactionsByDateRange(ctx context.Context, csvFileURI string, startDate, endDate time.Time) ([]domain.Transaction, error) {
+	transactions, err := s.lookup(ctx, csvFileURI, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var filteredTransactions []domain.Transaction
+	for _, transaction := range transactions {
+		if util.Between(transaction.Date, startDate, endDate) {
+			filteredTransactions = append(filteredTransactions, transaction)
+		}
+	}
+
+	sort.SliceStable(filteredTransactions, func(i, j int) bool {
+		return filteredTransactions[i].Date.After(filteredTransactions[j].Date)
+	})
+	return filteredTransactions, nil
+}
+
+func (s *CachedTransactionService) CalculateTotals(transactions []domain.Transaction) (totalIncome, totalExpenditure int64) {
+	return s.Service.CalculateTotals(transactions)
+}
+
+func (s *CachedTransactionService) lookup(ctx context.Context, uri string, start, end time.Time) ([]domain.Transaction, error) {
+	transactions, ok, err := s.Index.Lookup(ctx, uri, s.Variant, start, end)
+	if err != nil {
+		s.report(uri, err)
+	} else if ok {
+		return transactions, nil
+	}
+
+	// Taken before parsing: a snapshot taken afterwards could pair a newer input with
+	// the transactions of the version that was parsed
+	snapshot, err := s.Index.Snapshot(ctx, uri)
+	if err != nil {
+		s.report(uri, err)
+	}
+	all, err := s.Service.GetAllTransactions(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		if err := s.Index.Build(ctx, uri, s.Variant, snapshot, all); err != nil {
+			s.report(uri, err)
+		}
+	}
+	return all, nil
+}
+
+func (s *CachedTransactionService) report(uri string, err error) {
+	if s.OnError != nil {
+		s.OnError(uri, err)
+	}
+}
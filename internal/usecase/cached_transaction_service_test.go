@@ -0,0 +1,113 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// countingSource counts how often each input is opened
+type countingSource struct {
+	mapSource
+	opened map[string]int
+}
+
+func (c countingSource) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
+	c.opened[uri]++
+	return c.mapSource.Open(ctx, uri)
+}
+
+// memoryIndex keeps the transactions of each input and variant
+type memoryIndex struct {
+	entries   map[string][]domain.Transaction
+	lookupErr error
+}
+
+func (m *memoryIndex) Lookup(_ context.Context, uri, variant string, start, end time.Time) ([]domain.Transaction, bool, error) {
+	transactions, ok := m.entries[uri+"|"+variant]
+	return transactions, ok, m.lookupErr
+}
+
+func (m *memoryIndex) Snapshot(_ context.Context, uri string) (*usecase.InputSnapshot, error) {
+	return &usecase.InputSnapshot{Path: uri}, nil
+}
+
+func (m *memoryIndex) Build(_ context.Context, uri, variant string, _ *usecase.InputSnapshot, transactions []domain.Transaction) error {
+	m.entries[uri+"|"+variant] = transactions
+	return nil
+}
+
+var _ = Describe("CachedTransactionService", func() {
+	var (
+		ctx     context.Context
+		source  countingSource
+		index   *memoryIndex
+		service *usecase.CachedTransactionService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		source = countingSource{
+			mapSource: mapSource{"history.csv": "date,amount,content\n2025/01/05,2000,Salary\n2025/01/09,-300,Coffee\n2025/02/01,-50,Bus\n"},
+			opened:    map[string]int{},
+		}
+		index = &memoryIndex{entries: map[string][]domain.Transaction{}}
+		service = usecase.NewCachedTransactionService(usecase.NewTransactionService(source, parser.NewCSV()), index, "csv")
+	})
+
+	It("should parse an input once and answer later periods from the index", func() {
+		january, err := service.GetTransactionsByPeriod(ctx, "history.csv", 2025, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(january).To(HaveLen(2))
+		Expect(january[0].Content).To(Equal("Coffee"))
+
+		february, err := service.GetTransactionsByPeriod(ctx, "history.csv", 2025, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(february).To(HaveLen(1))
+
+		inRange, err := service.GetTransactionsByDateRange(ctx, "history.csv", time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inRange).To(HaveLen(2))
+
+		Expect(source.opened["history.csv"]).To(Equal(1))
+	})
+
+	It("should keep separate indexes per parser variant", func() {
+		_, err := service.GetAllTransactions(ctx, "history.csv")
+		Expect(err).NotTo(HaveOccurred())
+
+		service.Variant = "csv;delimiter=,"
+		_, err = service.GetAllTransactions(ctx, "history.csv")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(source.opened["history.csv"]).To(Equal(2))
+		Expect(index.entries).To(HaveLen(2))
+	})
+
+	It("should parse the input and report when the index fails", func() {
+		index.lookupErr = errors.New("disk full")
+		var reported []error
+		service.OnError = func(uri string, err error) { reported = append(reported, err) }
+
+		transactions, err := service.GetTransactionsByPeriod(ctx, "history.csv", 2025, 1)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(2))
+		Expect(reported).To(ConsistOf(MatchError("disk full")))
+	})
+
+	It("should validate the period before using the index", func() {
+		_, err := service.GetTransactionsByPeriod(ctx, "history.csv", 2025, 13)
+
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+		Expect(source.opened).To(BeEmpty())
+	})
+})
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"mf-statement/internal/domain"
+	"sort"
+	"time"
+)
+
+// ComparisonBasis selects the period a month is compared against
+type ComparisonBasis string
+
+const (
+	// CompareToPrevious compares with the month before
+	CompareToPrevious ComparisonBasis = "previous"
+	// CompareToLastYear compares with the same month a year earlier
+	CompareToLastYear ComparisonBasis = "last-year"
+)
+
+func ParseComparisonBasis(basis string) (ComparisonBasis, error) {
+	switch ComparisonBasis(basis) {
+	case CompareToPrevious, CompareToLastYear:
+		return ComparisonBasis(basis), nil
+	default:
+		return "", domain.NewValidationError("invalid comparison period", map[string]interface{}{
+			"compare_to": basis,
+			"allowed":    []ComparisonBasis{CompareToPrevious, CompareToLastYear},
+		})
+	}
+}
+
+// Period returns the month compared with year and month
+func (b ComparisonBasis) Period(year, month int) (int, int) {
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	if b == CompareToLastYear {
+		start = start.AddDate(-1, 0, 0)
+	} else {
+		start = start.AddDate(0, -1, 0)
+	}
+	return start.Year(), int(start.Month())
+}
+
+// ComparisonService compares the statements of two months
+type ComparisonService struct {
+	TransactionService TransactionService
+	// Deduplicator is optional; nil keeps every transaction
+	Deduplicator *Deduplicator
+	Validator    Validator
+}
+
+func NewComparisonService(transactionService TransactionService, deduplicator *Deduplicator) *ComparisonService {
+	return &ComparisonService{
+		TransactionService: transactionService,
+		Deduplicator:       deduplicator,
+		Validator:          NewPeriodValidator(),
+	}
+}
+
+// Compare totals the month and its comparison period and reports the changes. Spend
+// per category is included when any transaction of either period has a category.
+func (s *ComparisonService) Compare(ctx context.Context, csvFileURI string, year, month int, basis ComparisonBasis) (domain.Comparison, error) {
+	if err := s.Validator.ValidatePeriod(year, month); err != nil {
+		return domain.Comparison{}, err
+	}
+	previousYear, previousMonth := basis.Period(year, month)
+
+	// One read covers both months, which are split in memory
+	start := time.Date(previousYear, time.Month(previousMonth), 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0).Add(-time.Nanosecond)
+	transactions, err := s.TransactionService.GetTransactionsByDateRange(ctx, csvFileURI, start, end)
+	if err != nil {
+		return domain.Comparison{}, err
+	}
+	current, err := s.figures(FilterByPeriod(transactions, year, month))
+	if err != nil {
+		return domain.Comparison{}, err
+	}
+	previous, err := s.figures(FilterByPeriod(transactions, previousYear, previousMonth))
+	if err != nil {
+		return domain.Comparison{}, err
+	}
+
+	comparison := domain.Comparison{
+		Period:           periodDisplay(year, month),
+		ComparedTo:       periodDisplay(previousYear, previousMonth),
+		Income:           domain.NewChange(current.income, previous.income),
+		Expenditure:      domain.NewChange(current.expenditure, previous.expenditure),
+		Net:              domain.NewChange(current.income+current.expenditure, previous.income+previous.expenditure),
+		TransactionCount: domain.NewChange(int64(current.count), int64(previous.count)),
+		IncomeCount:      domain.NewChange(int64(current.incomeCount), int64(previous.incomeCount)),
+		ExpenseCount:     domain.NewChange(int64(current.expenseCount), int64(previous.expenseCount)),
+	}
+
+	if current.categorized || previous.categorized {
+		categories := make(map[string]bool)
+		for category := range current.spend {
+			categories[category] = true
+		}
+		for category := range previous.spend {
+			categories[category] = true
+		}
+		for category := range categories {
+			comparison.Categories = append(comparison.Categories, domain.CategoryChange{
+				Category: category,
+				Change:   domain.NewChange(current.spend[category], previous.spend[category]),
+			})
+		}
+		// Categories in name order, the uncategorized spend last
+		sort.Slice(comparison.Categories, func(i, j int) bool {
+			a, b := comparison.Categories[i].Category, comparison.Categories[j].Category
+			if (a == domain.UncategorizedLabel) != (b == domain.UncategorizedLabel) {
+				return b == domain.UncategorizedLabel
+			}
+			return a < b
+		})
+	}
+	return comparison, nil
+}
+
+type periodFigures struct {
+	income, expenditure              int64
+	count, incomeCount, expenseCount int
+	spend                            map[string]int64
+	categorized                      bool
+}
+
+func (s *ComparisonService) figures(transactions []domain.Transaction) (periodFigures, error) {
+	kept, _, err := s.Deduplicator.Dedupe(transactions)
+	if err != nil {
+		return periodFigures{}, err
+	}
+
+	figures := periodFigures{count: len(kept), spend: make(map[string]int64)}
+	figures.income, figures.expenditure = s.TransactionService.CalculateTotals(kept)
+	for _, tx := range kept {
+		if tx.Category != "" {
+			figures.categorized = true
+		}
+		if tx.IsIncome() {
+			figures.incomeCount++
+		} else if tx.IsExpense() {
+			figures.expenseCount++
+			category := tx.Category
+			if category == "" {
+				category = domain.UncategorizedLabel
+			}
+			figures.spend[category] += tx.Amount
+		}
+	}
+	return figures, nil
+}
+
+func periodDisplay(year, month int) string {
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).Format("2006/01")
+}
@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+var _ = Describe("ComparisonService", func() {
+	var (
+		ctx     context.Context
+		service *usecase.ComparisonService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		// Categories come from JSON inputs; the CSV format has no category column
+		source := mapSource{"ledger.json": `[
+			{"date": "2024-01-25", "amount": 3000, "content": "Salary", "category": "income"},
+			{"date": "2024-01-26", "amount": -500, "content": "Rent", "category": "housing"},
+			{"date": "2024-12-05", "amount": 2000, "content": "Salary", "category": "income"},
+			{"date": "2024-12-09", "amount": -300, "content": "Groceries", "category": "food"},
+			{"date": "2024-12-20", "amount": -100, "content": "Cinema"},
+			{"date": "2025-01-05", "amount": 2500, "content": "Salary", "category": "income"},
+			{"date": "2025-01-09", "amount": -450, "content": "Groceries", "category": "food"},
+			{"date": "2025-01-10", "amount": -500, "content": "Rent", "category": "housing"}
+		]`}
+		service = usecase.NewComparisonService(usecase.NewTransactionService(source, parser.NewJSON()), nil)
+	})
+
+	It("should compare a month with the month before", func() {
+		comparison, err := service.Compare(ctx, "ledger.json", 2025, 1, usecase.CompareToPrevious)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comparison.Period).To(Equal("2025/01"))
+		Expect(comparison.ComparedTo).To(Equal("2024/12"))
+		Expect(comparison.Income).To(Equal(domain.NewChange(2500, 2000)))
+		Expect(comparison.Expenditure).To(Equal(domain.NewChange(-950, -400)))
+		Expect(comparison.Net).To(Equal(domain.NewChange(1550, 1600)))
+		Expect(comparison.TransactionCount).To(Equal(domain.NewChange(3, 3)))
+		Expect(comparison.ExpenseCount).To(Equal(domain.NewChange(2, 2)))
+		Expect(comparison.Categories).To(Equal([]domain.CategoryChange{
+			{Category: "food", Change: domain.NewChange(-450, -300)},
+			{Category: "housing", Change: domain.NewChange(-500, 0)},
+			{Category: domain.UncategorizedLabel, Change: domain.NewChange(0, -100)},
+		}))
+	})
+
+	It("should compare a month with the same month last year", func() {
+		comparison, err := service.Compare(ctx, "ledger.json", 2025, 1, usecase.CompareToLastYear)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comparison.ComparedTo).To(Equal("2024/01"))
+		Expect(comparison.Income).To(Equal(domain.NewChange(2500, 3000)))
+		Expect(comparison.Expenditure).To(Equal(domain.NewChange(-950, -500)))
+	})
+
+	It("should leave out categories when no transaction has one", func() {
+		source := mapSource{"plain.csv": "date,amount,content\n2025/01/05,-200,Groceries\n2024/12/05,-100,Groceries\n"}
+		service = usecase.NewComparisonService(usecase.NewTransactionService(source, parser.NewCSV()), nil)
+
+		comparison, err := service.Compare(ctx, "plain.csv", 2025, 1, usecase.CompareToPrevious)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comparison.Categories).To(BeEmpty())
+		Expect(*comparison.Expenditure.PercentChange).To(Equal(-100.0))
+	})
+
+	It("should read the inputs once for both months", func() {
+		source := countingSource{mapSource: mapSource{"plain.csv": "date,amount,content\n2025/01/05,-200,Groceries\n2024/01/05,-100,Groceries\n"}, opened: map[string]int{}}
+		service = usecase.NewComparisonService(usecase.NewTransactionService(source, parser.NewCSV()), nil)
+
+		comparison, err := service.Compare(ctx, "plain.csv", 2025, 1, usecase.CompareToLastYear)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comparison.Expenditure).To(Equal(domain.NewChange(-200, -100)))
+		Expect(source.opened).To(Equal(map[string]int{"plain.csv": 1}))
+	})
+
+	It("should reject unknown comparison periods", func() {
+		_, err := usecase.ParseComparisonBasis("last-week")
+
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"fmt"
+	"mf-statement/internal/domain"
+	"strings"
+)
+
+// DedupeMode selects what happens to duplicate transactions
+type DedupeMode string
+
+const (
+	// DedupeOff keeps every transaction
+	DedupeOff DedupeMode = ""
+	// DedupeReport moves duplicates into the statement's duplicates section
+	DedupeReport DedupeMode = "report"
+	// DedupeDrop removes duplicates silently
+	DedupeDrop DedupeMode = "drop"
+	// DedupeFail rejects inputs containing duplicates
+	DedupeFail DedupeMode = "fail"
+)
+
+// Dedupe key fields
+const (
+	DedupeFieldDate     = "date"
+	DedupeFieldAmount   = "amount"
+	DedupeFieldContent  = "content"
+	DedupeFieldCategory = "category"
+	DedupeFieldID       = "id"
+)
+
+// DefaultDedupeFields identifies a transaction by date, amount and normalized content
+var DefaultDedupeFields = []string{DedupeFieldDate, DedupeFieldAmount, DedupeFieldContent}
+
+// ParseDedupeMode validates a mode name; "off" and "" disable de-duplication
+func ParseDedupeMode(mode string) (DedupeMode, error) {
+	switch DedupeMode(mode) {
+	case DedupeReport, DedupeDrop, DedupeFail:
+		return DedupeMode(mode), nil
+	case DedupeOff, "off":
+		return DedupeOff, nil
+	default:
+		return DedupeOff, domain.NewValidationError("invalid dedupe mode", map[string]interface{}{
+			"mode":    mode,
+			"allowed": []DedupeMode{DedupeReport, DedupeDrop, DedupeFail},
+		})
+	}
+}
+
+// Deduplicator finds transactions that appear in more than one input, as happens with
+// overlapping bank exports. Identical transactions within a single input are kept: two
+// equal purchases on the same day are common, so an input is never a duplicate of itself.
+// Inputs are told apart by Source, which is empty when a single input is read, so a
+// single input never has duplicates.
+type Deduplicator struct {
+	Mode   DedupeMode
+	Fields []string
+}
+
+func NewDeduplicator(mode DedupeMode, fields []string) (*Deduplicator, error) {
+	if len(fields) == 0 {
+		fields = DefaultDedupeFields
+	}
+	for _, field := range fields {
+		switch field {
+		case DedupeFieldDate, DedupeFieldAmount, DedupeFieldContent, DedupeFieldCategory, DedupeFieldID:
+		default:
+			return nil, domain.NewValidationError("invalid dedupe key field", map[string]interface{}{
+				"field": field,
+			})
+		}
+	}
+	return &Deduplicator{Mode: mode, Fields: fields}, nil
+}
+
+// Dedupe splits transactions into the ones to keep and the duplicates. The first
+// occurrence is kept; in report and drop mode duplicates are removed from kept.
+func (d *Deduplicator) Dedupe(transactions []domain.Transaction) (kept, duplicates []domain.Transaction, err error) {
+	if d == nil || d.Mode == DedupeOff {
+		return transactions, nil, nil
+	}
+
+	// A key occurs as often as in the input that has it most often; occurrences
+	// beyond what other inputs already contributed are duplicates
+	keptCount := make(map[string]int)
+	seenPerSource := make(map[string]map[string]int)
+
+	for _, tx := range transactions {
+		key := d.Key(tx)
+		if seenPerSource[key] == nil {
+			seenPerSource[key] = make(map[string]int)
+		}
+		seenPerSource[key][tx.Source]++
+
+		if seenPerSource[key][tx.Source] <= keptCount[key] {
+			duplicates = append(duplicates, tx)
+			continue
+		}
+		keptCount[key]++
+		kept = append(kept, tx)
+	}
+
+	if d.Mode == DedupeFail && len(duplicates) > 0 {
+		first := duplicates[0]
+		return nil, nil, domain.NewValidationError(fmt.Sprintf("found %d duplicate transactions", len(duplicates)), map[string]interface{}{
+			"first":  domain.NewTransactionDTO(first),
+			"source": first.Source,
+		})
+	}
+	return kept, duplicates, nil
+}
+
+// Key identifies a transaction by the configured fields. Transactions missing one of
+// them, such as a CSV row without an id, are identified by the default fields instead,
+// so they are not all taken for copies of each other.
+func (d *Deduplicator) Key(tx domain.Transaction) string {
+	if key, ok := dedupeKey(tx, d.Fields); ok {
+		return key
+	}
+	key, _ := dedupeKey(tx, DefaultDedupeFields)
+	return key
+}
+
+// dedupeKey joins the fields of a transaction, reporting false when one is empty
+func dedupeKey(tx domain.Transaction, fields []string) (string, bool) {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		switch field {
+		case DedupeFieldDate:
+			parts[i] = tx.Date.Format(domain.CSVDateLayout)
+		case DedupeFieldAmount:
+			parts[i] = fmt.Sprintf("%d", tx.Amount)
+		case DedupeFieldContent:
+			parts[i] = NormalizeContent(tx.Content)
+		case DedupeFieldCategory:
+			parts[i] = NormalizeContent(tx.Category)
+		case DedupeFieldID:
+			parts[i] = tx.ID
+		}
+		if parts[i] == "" {
+			return "", false
+		}
+	}
+	return strings.Join(parts, "\x00"), true
+}
+
+// NormalizeContent folds case and whitespace so "COFFEE  shop" matches "Coffee shop"
+func NormalizeContent(content string) string {
+	return strings.ToLower(strings.Join(strings.Fields(content), " "))
+}
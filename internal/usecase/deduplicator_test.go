@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+var _ = Describe("Deduplicator", func() {
+	var transactions []domain.Transaction
+
+	tx := func(day int, amount int64, content, source string) domain.Transaction {
+		return domain.Transaction{Date: time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), Amount: amount, Content: content, Source: source}
+	}
+
+	BeforeEach(func() {
+		transactions = []domain.Transaction{
+			tx(5, 2000, "Salary", "old.csv"),
+			tx(9, -300, "Coffee  Shop", "old.csv"),
+			tx(9, -300, "Coffee Shop", "old.csv"),
+			tx(5, 2000, "SALARY", "new.csv"),
+			tx(9, -300, "coffee shop", "new.csv"),
+			tx(20, -100, "Books", "new.csv"),
+		}
+	})
+
+	newDeduplicator := func(mode usecase.DedupeMode, fields ...string) *usecase.Deduplicator {
+		deduplicator, err := usecase.NewDeduplicator(mode, fields)
+		Expect(err).NotTo(HaveOccurred())
+		return deduplicator
+	}
+
+	It("should keep repeats within one input and drop those seen in another", func() {
+		kept, duplicates, err := newDeduplicator(usecase.DedupeDrop).Dedupe(transactions)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kept).To(HaveLen(4))
+		Expect(duplicates).To(ConsistOf(transactions[3], transactions[4]))
+	})
+
+	It("should fail on duplicates in fail mode", func() {
+		_, _, err := newDeduplicator(usecase.DedupeFail).Dedupe(transactions)
+
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("found 2 duplicate transactions"))
+	})
+
+	It("should use the configured key", func() {
+		kept, duplicates, err := newDeduplicator(usecase.DedupeDrop, usecase.DedupeFieldDate, usecase.DedupeFieldAmount).Dedupe([]domain.Transaction{
+			tx(5, 2000, "Salary", "old.csv"),
+			tx(5, 2000, "Payroll ACME", "new.csv"),
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kept).To(HaveLen(1))
+		Expect(duplicates).To(HaveLen(1))
+	})
+
+	It("should fall back to the default key for transactions without the configured fields", func() {
+		withID := func(t domain.Transaction, id string) domain.Transaction {
+			t.ID = id
+			return t
+		}
+		kept, duplicates, err := newDeduplicator(usecase.DedupeDrop, usecase.DedupeFieldID).Dedupe([]domain.Transaction{
+			withID(tx(5, 2000, "Salary", "bank.qfx"), "FIT1"),
+			tx(9, -300, "Coffee", "card.csv"),
+			tx(20, -100, "Books", "card.csv"),
+			withID(tx(5, 2000, "Salary", "bank-feb.qfx"), "FIT1"),
+			tx(20, -100, "Books", "export.csv"),
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kept).To(HaveLen(3))
+		Expect(duplicates).To(HaveLen(2))
+		Expect(duplicates[1].Source).To(Equal("export.csv"))
+	})
+
+	It("should never report duplicates within a single input", func() {
+		kept, duplicates, err := newDeduplicator(usecase.DedupeReport).Dedupe([]domain.Transaction{
+			tx(9, -300, "Coffee", ""),
+			tx(9, -300, "Coffee", ""),
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kept).To(HaveLen(2))
+		Expect(duplicates).To(BeEmpty())
+	})
+
+	It("should reject unknown key fields and modes", func() {
+		_, err := usecase.NewDeduplicator(usecase.DedupeDrop, []string{"balance"})
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+
+		_, err = usecase.ParseDedupeMode("merge")
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+
+	Context("BuildStatement", func() {
+		It("should total kept transactions and list duplicates in report mode", func() {
+			service := usecase.NewTransactionService(nil, nil)
+
+			statement, err := usecase.BuildStatement(service, newDeduplicator(usecase.DedupeReport), "2025/01", transactions)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statement.TotalIncome).To(Equal(int64(2000)))
+			Expect(statement.TotalExpenditure).To(Equal(int64(-700)))
+			Expect(statement.Transactions).To(HaveLen(4))
+			Expect(statement.Duplicates).To(HaveLen(2))
+			Expect(statement.Duplicates[0].Source).To(Equal("new.csv"))
+		})
+
+		It("should leave the statement untouched without a deduplicator", func() {
+			statement, err := usecase.BuildStatement(usecase.NewTransactionService(nil, nil), nil, "2025/01", transactions)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statement.Transactions).To(HaveLen(6))
+			Expect(statement.Duplicates).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mf-statement/internal/domain"
+	"time"
+)
+
+// ImportedFile records an input stored in a TransactionStore
+type ImportedFile struct {
+	URI string
+	// Hash is the SHA-256 of the input's content, identifying re-imports of the same file
+	Hash       string
+	ImportedAt time.Time
+}
+
+// TransactionStore accumulates transactions across imports. Transactions are keyed by
+// fingerprint, so rows repeated by overlapping exports are stored once.
+type TransactionStore interface {
+	HasFile(ctx context.Context, hash string) (bool, error)
+	// Add records the file and inserts the transactions whose fingerprints are not
+	// stored yet, returning how many were inserted
+	Add(ctx context.Context, file ImportedFile, transactions []domain.Transaction, fingerprints []string) (int, error)
+}
+
+// ImportResult summarizes the import of one input
+type ImportResult struct {
+	URI  string
+	Hash string
+	// Skipped is set when the same content was imported before
+	Skipped bool
+	Rows    int
+	Added   int
+}
+
+// Duplicates is the number of rows that were already stored by earlier imports
+func (r ImportResult) Duplicates() int {
+	return r.Rows - r.Added
+}
+
+// ImportService parses inputs into a TransactionStore. Importing is idempotent: a file
+// whose content was imported before is skipped, and rows of new files are only added
+// when no earlier import stored them.
+type ImportService struct {
+	Source Source
+	Parser Parser
+	Store  TransactionStore
+	Now    func() time.Time
+}
+
+func NewImportService(source Source, parser Parser, store TransactionStore) *ImportService {
+	return &ImportService{
+		Source: source,
+		Parser: parser,
+		Store:  store,
+		Now:    time.Now,
+	}
+}
+
+func (s *ImportService) Import(ctx context.Context, uri string) (ImportResult, error) {
+	reader, err := s.Source.Open(ctx, uri)
+	if err != nil {
+		return ImportResult{}, domain.NewIOError("failed to open input", err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return ImportResult{}, domain.NewIOError("failed to read input", err)
+	}
+	sum := sha256.Sum256(content)
+	result := ImportResult{URI: uri, Hash: hex.EncodeToString(sum[:])}
+
+	imported, err := s.Store.HasFile(ctx, result.Hash)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if imported {
+		result.Skipped = true
+		return result, nil
+	}
+
+	transactions, err := ParseInput(ctx, s.Parser, uri, bytes.NewReader(content))
+	if err != nil {
+		return ImportResult{}, domain.NewParseError(fmt.Sprintf("failed to parse %s", uri), err)
+	}
+	for i := range transactions {
+		transactions[i].Source = uri
+	}
+
+	file := ImportedFile{URI: uri, Hash: result.Hash, ImportedAt: s.Now()}
+	result.Rows = len(transactions)
+	result.Added, err = s.Store.Add(ctx, file, transactions, Fingerprints(transactions))
+	if err != nil {
+		return ImportResult{}, err
+	}
+	return result, nil
+}
+
+// Fingerprints identifies each transaction by its date, amount, normalized content,
+// category and bank ID. Repeats within one input are numbered, so two equal purchases
+// on the same day get distinct fingerprints while a later export containing both again
+// matches them.
+func Fingerprints(transactions []domain.Transaction) []string {
+	key := Deduplicator{Fields: []string{DedupeFieldDate, DedupeFieldAmount, DedupeFieldContent, DedupeFieldCategory, DedupeFieldID}}
+	occurrences := make(map[string]int)
+
+	fingerprints := make([]string, len(transactions))
+	for i, tx := range transactions {
+		k := key.Key(tx)
+		occurrences[k]++
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", k, occurrences[k])))
+		fingerprints[i] = hex.EncodeToString(sum[:16])
+	}
+	return fingerprints
+}
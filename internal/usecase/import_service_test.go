@@ -0,0 +1,102 @@
+package usecase_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// memoryStore keeps imported transactions by fingerprint
+type memoryStore struct {
+	files        map[string]usecase.ImportedFile
+	transactions map[string]domain.Transaction
+}
+
+func (m *memoryStore) HasFile(_ context.Context, hash string) (bool, error) {
+	_, ok := m.files[hash]
+	return ok, nil
+}
+
+func (m *memoryStore) Add(_ context.Context, file usecase.ImportedFile, transactions []domain.Transaction, fingerprints []string) (int, error) {
+	m.files[file.Hash] = file
+	added := 0
+	for i, tx := range transactions {
+		if _, ok := m.transactions[fingerprints[i]]; !ok {
+			m.transactions[fingerprints[i]] = tx
+			added++
+		}
+	}
+	return added, nil
+}
+
+var _ = Describe("ImportService", func() {
+	var (
+		ctx     context.Context
+		store   *memoryStore
+		service *usecase.ImportService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		store = &memoryStore{files: map[string]usecase.ImportedFile{}, transactions: map[string]domain.Transaction{}}
+		source := mapSource{
+			"december.csv": "date,amount,content\n2024/12/30,-300,Coffee\n2025/01/02,-300,Coffee\n2025/01/02,-300,Coffee\n",
+			"january.csv":  "date,amount,content\n2025/01/02,-300,COFFEE\n2025/01/02,-300,Coffee\n2025/01/02,-300,Coffee\n2025/01/05,2000,Salary\n",
+			"broken.csv":   "date,amount,content\nnot-a-date,1,Broken\n",
+		}
+		service = usecase.NewImportService(source, parser.NewCSV(), store)
+		service.Now = func() time.Time { return time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC) }
+	})
+
+	It("should add only the rows earlier imports did not store", func() {
+		result, err := service.Import(ctx, "december.csv")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Rows).To(Equal(3))
+		Expect(result.Added).To(Equal(3))
+
+		result, err = service.Import(ctx, "january.csv")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Rows).To(Equal(4))
+		Expect(result.Added).To(Equal(2))
+		Expect(result.Duplicates()).To(Equal(2))
+		Expect(store.transactions).To(HaveLen(5))
+	})
+
+	It("should skip inputs whose content was imported before", func() {
+		first, err := service.Import(ctx, "december.csv")
+		Expect(err).NotTo(HaveOccurred())
+
+		again, err := service.Import(ctx, "december.csv")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again.Skipped).To(BeTrue())
+		Expect(again.Hash).To(Equal(first.Hash))
+		Expect(store.files[first.Hash].URI).To(Equal("december.csv"))
+		Expect(store.files[first.Hash].ImportedAt).To(Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should not record inputs that fail to parse", func() {
+		_, err := service.Import(ctx, "broken.csv")
+
+		Expect(domain.IsParseError(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("broken.csv"))
+		Expect(store.files).To(BeEmpty())
+	})
+
+	It("should number repeated transactions within an input", func() {
+		coffee := domain.Transaction{Date: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), Amount: -300, Content: "Coffee"}
+		shouted := coffee
+		shouted.Content = "  COFFEE "
+
+		fingerprints := usecase.Fingerprints([]domain.Transaction{coffee, shouted})
+
+		Expect(fingerprints[0]).NotTo(Equal(fingerprints[1]))
+		Expect(usecase.Fingerprints([]domain.Transaction{shouted})).To(Equal(fingerprints[:1]))
+	})
+})
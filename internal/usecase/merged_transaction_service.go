@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/util"
+	"time"
+)
+
+// MergedTransactionService reads several inputs through Service and presents them as a
+// single transaction stream, tagging every transaction with the input it came from.
+// The URI argument of the interface methods must be empty: the inputs are URIs, and
+// any other URI is rejected rather than silently answered with the merged inputs.
+type MergedTransactionService struct {
+	Service   TransactionService
+	URIs      []string
+	Validator Validator
+}
+
+func NewMergedTransactionService(service TransactionService, uris []string) *MergedTransactionService {
+	return &MergedTransactionService{
+		Service:   service,
+		URIs:      uris,
+		Validator: NewPeriodValidator(),
+	}
+}
+
+func (s *MergedTransactionService) GetAllTransactions(ctx context.Context, uri string) ([]domain.Transaction, error) {
+	if uri != "" {
+		return nil, domain.NewValidationError(fmt.Sprintf("merged inputs cannot be queried for %s", uri), map[string]interface{}{
+			"uri":    uri,
+			"inputs": s.URIs,
+		})
+	}
+
+	var merged []domain.Transaction
+	for _, source := range s.URIs {
+		transactions, err := s.Service.GetAllTransactions(ctx, source)
+		if err != nil {
+			return nil, withSource(err, source)
+		}
+		for i := range transactions {
+			transactions[i].Source = source
+		}
+		merged = append(merged, transactions...)
+	}
+
+	sortNewestFirst(merged)
+	return merged, nil
+}
+
+func (s *MergedTransactionService) GetTransactionsByPeriod(ctx context.Context, uri string, year, month int) ([]domain.Transaction, error) {
+	if err := s.Validator.ValidatePeriod(year, month); err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.GetAllTransactions(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	return FilterByPeriod(transactions, year, month), nil
+}
+
+func (s *MergedTransactionService) GetTransactionsByDateRange(ctx context.Context, uri string, startDate, endDate time.Time) ([]domain.Transaction, error) {
+	transactions, err := s.GetAllTransactions(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []domain.Transaction
+	for _, transaction := range transactions {
+		if util.Between(transaction.Date, startDate, endDate) {
+			filtered = append(filtered, transaction)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *MergedTransactionService) CalculateTotals(transactions []domain.Transaction) (totalIncome, totalExpenditure int64) {
+	return s.Service.CalculateTotals(transactions)
+}
+
+// withSource names the failing input while keeping domain errors recognisable by type
+func withSource(err error, source string) error {
+	var domainErr domain.DomainError
+	if !errors.As(err, &domainErr) {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	domainErr.Message = source + ": " + domainErr.Message
+	return domainErr
+}
@@ -0,0 +1,84 @@
+package usecase_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"mf-statement/internal/adapters/out/parser"
+	"mf-statement/internal/domain"
+	"mf-statement/internal/usecase"
+)
+
+// mapSource serves CSV content by URI
+type mapSource map[string]string
+
+func (m mapSource) Open(_ context.Context, uri string) (io.ReadCloser, error) {
+	content, ok := m[uri]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+var _ = Describe("MergedTransactionService", func() {
+	var (
+		ctx    context.Context
+		source mapSource
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		source = mapSource{
+			"checking.csv": "date,amount,content\n2025/01/05,2000,Salary\n2025/02/01,-50,Bus\n",
+			"savings.csv":  "date,amount,content\n2025/01/05,100,Interest\n2025/01/20,-500,Transfer\n",
+			"broken.csv":   "date,amount,content\nnot-a-date,1,Broken\n",
+		}
+	})
+
+	newService := func(uris ...string) *usecase.MergedTransactionService {
+		return usecase.NewMergedTransactionService(usecase.NewTransactionService(source, parser.NewCSV()), uris)
+	}
+
+	It("should merge every input, tagging each transaction with its source", func() {
+		transactions, err := newService("checking.csv", "savings.csv").GetAllTransactions(ctx, "")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(4))
+		Expect(transactions[0].Content).To(Equal("Bus"))
+		Expect(transactions[0].Source).To(Equal("checking.csv"))
+		Expect(transactions[1].Source).To(Equal("savings.csv"))
+		// Same day transactions keep the input order
+		Expect(transactions[2].Content).To(Equal("Salary"))
+		Expect(transactions[3].Content).To(Equal("Interest"))
+	})
+
+	It("should filter the merged stream by period", func() {
+		service := newService("checking.csv", "savings.csv")
+
+		transactions, err := service.GetTransactionsByPeriod(ctx, "", 2025, 1)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transactions).To(HaveLen(3))
+		totalIncome, totalExpenditure := service.CalculateTotals(transactions)
+		Expect(totalIncome).To(Equal(int64(2100)))
+		Expect(totalExpenditure).To(Equal(int64(-500)))
+	})
+
+	It("should name the failing input in errors", func() {
+		_, err := newService("checking.csv", "broken.csv").GetAllTransactions(ctx, "")
+
+		Expect(err).To(MatchError(ContainSubstring("broken.csv: failed to parse CSV")))
+		Expect(domain.IsParseError(err)).To(BeTrue())
+	})
+
+	It("should reject queries for a single URI", func() {
+		_, err := newService("checking.csv", "savings.csv").GetTransactionsByPeriod(ctx, "checking.csv", 2025, 1)
+
+		Expect(domain.IsValidationError(err)).To(BeTrue())
+	})
+})
@@ -63,3 +63,28 @@ func (s *OptimizedStatementService) GenerateStatementByDateRangeOptimized(ctx co
 
 	return nil
 }
+
+// StreamMonthlyStatement writes each transaction of the period to the stream writer as it is
+// parsed and finishes with a summary. Transactions are emitted in input order rather than
+// sorted, so the whole period never has to be held in memory.
+func (s *OptimizedStatementService) StreamMonthlyStatement(ctx context.Context, csvFileURI string, periodDisplay string, year, month int, streamWriter output.StreamWriter) error {
+	summary := domain.Summary{Period: periodDisplay}
+
+	err := s.OptimizedTransactionService.StreamTransactionsByPeriod(ctx, csvFileURI, year, month, func(transaction domain.Transaction) error {
+		addToSummary(&summary, transaction)
+
+		if err := streamWriter.WriteTransaction(ctx, transaction); err != nil {
+			return domain.NewIOError("failed to write transaction", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := streamWriter.WriteSummary(ctx, summary); err != nil {
+		return domain.NewIOError("failed to write statement summary", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"time"
@@ -144,4 +145,62 @@ var _ = Describe("Optimized Services", func() {
 			Expect(outputPath).To(BeAnExistingFile())
 		})
 	})
+
+	Context("StreamMonthlyStatement", func() {
+		It("should stream period transactions and finish with a summary", func() {
+			csvContent := `date,amount,content
+2025/01/01,2000,January Salary
+2025/02/01,3000,February Salary
+2025/01/15,-500,January Expense`
+			Expect(os.WriteFile(csvPath, []byte(csvContent), 0644)).To(Succeed())
+
+			source := in.NewCSVFileSource()
+			transactionService := usecase.NewOptimizedTransactionService(source)
+			service := usecase.NewOptimizedStatementService(transactionService, nil)
+			streamWriter := &recordingStreamWriter{}
+
+			err := service.StreamMonthlyStatement(ctx, csvPath, "2025/01", 2025, 1, streamWriter)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(streamWriter.transactions).To(HaveLen(2))
+			Expect(streamWriter.transactions[0].Content).To(Equal("January Salary"))
+			Expect(streamWriter.summary).To(Equal(domain.Summary{
+				Period:           "2025/01",
+				TotalIncome:      2000,
+				TotalExpenditure: -500,
+				TransactionCount: 2,
+			}))
+		})
+
+		It("should return IO error when the stream writer fails", func() {
+			Expect(os.WriteFile(csvPath, []byte("date,amount,content\n2025/01/01,2000,Salary"), 0644)).To(Succeed())
+
+			source := in.NewCSVFileSource()
+			service := usecase.NewOptimizedStatementService(usecase.NewOptimizedTransactionService(source), nil)
+
+			err := service.StreamMonthlyStatement(ctx, csvPath, "2025/01", 2025, 1, &recordingStreamWriter{err: errors.New("disk full")})
+
+			Expect(err).To(HaveOccurred())
+			Expect(domain.IsIOError(err)).To(BeTrue())
+		})
+	})
 })
+
+type recordingStreamWriter struct {
+	transactions []domain.Transaction
+	summary      domain.Summary
+	err          error
+}
+
+func (r *recordingStreamWriter) WriteTransaction(ctx context.Context, tx domain.Transaction) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.transactions = append(r.transactions, tx)
+	return nil
+}
+
+func (r *recordingStreamWriter) WriteSummary(ctx context.Context, summary domain.Summary) error {
+	r.summary = summary
+	return nil
+}
@@ -11,7 +11,7 @@ import (
 // OptimizedTransactionService provides memory-efficient transaction processing
 type OptimizedTransactionService struct {
 	Source         Source
-	FilteredParser *parser.FilteredCSVParser
+	FilteredParser FilteredParser
 	Validator      Validator
 }
 
@@ -71,6 +71,36 @@ func (s *OptimizedTransactionService) GetTransactionsByDateRangeOptimized(ctx co
 	return transactions, nil
 }
 
+// StreamTransactionsByPeriod hands each transaction of the period to emit in file order,
+// keeping memory usage constant regardless of input size
+func (s *OptimizedTransactionService) StreamTransactionsByPeriod(ctx context.Context, csvFileURI string, year, month int, emit func(domain.Transaction) error) error {
+	if err := s.Validator.ValidatePeriod(year, month); err != nil {
+		return err
+	}
+
+	csvReader, err := s.Source.Open(ctx, csvFileURI)
+	if err != nil {
+		return domain.NewIOError("failed to open CSV source", err)
+	}
+	defer csvReader.Close()
+
+	var emitErr error
+	err = s.FilteredParser.StreamWithFilter(ctx, csvReader, func(transaction domain.Transaction) bool {
+		return transaction.Date.Year() == year && int(transaction.Date.Month()) == month
+	}, func(transaction domain.Transaction) error {
+		emitErr = emit(transaction)
+		return emitErr
+	})
+	if emitErr != nil {
+		return emitErr
+	}
+	if err != nil {
+		return domain.NewParseError("failed to parse CSV", err)
+	}
+
+	return nil
+}
+
 // CalculateTotalsOptimized calculates totals with early exit for large datasets
 func (s *OptimizedTransactionService) CalculateTotalsOptimized(transactions []domain.Transaction) (totalIncome, totalExpenditure int64) {
 	for _, transaction := range transactions {
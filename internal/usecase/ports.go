@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"mf-statement/internal/domain"
+	"time"
 )
 
 type Source interface {
@@ -13,3 +14,49 @@ type Source interface {
 type Parser interface {
 	Parse(ctx context.Context, reader io.Reader) ([]domain.Transaction, error)
 }
+
+// NamedParser is a Parser that also takes the input's URI into account, e.g. to
+// recognise its format by the file extension
+type NamedParser interface {
+	Parser
+	ParseNamed(ctx context.Context, uri string, reader io.Reader) ([]domain.Transaction, error)
+}
+
+// ParseInput parses the input read from uri, passing the URI on to named parsers
+func ParseInput(ctx context.Context, parser Parser, uri string, reader io.Reader) ([]domain.Transaction, error) {
+	if named, ok := parser.(NamedParser); ok {
+		return named.ParseNamed(ctx, uri, reader)
+	}
+	return parser.Parse(ctx, reader)
+}
+
+// StreamingParser is a Parser that can hand over each transaction as it is read
+type StreamingParser interface {
+	Parser
+	Stream(ctx context.Context, reader io.Reader, emit func(domain.Transaction) error) error
+}
+
+// NamedStreamingParser streams inputs whose parser depends on the URI, like NamedParser
+type NamedStreamingParser interface {
+	StreamNamed(ctx context.Context, uri string, reader io.Reader, emit func(domain.Transaction) error) error
+}
+
+// StreamInput hands the transactions of the input read from uri to emit as they are
+// parsed. Parsers that cannot stream report a validation error.
+func StreamInput(ctx context.Context, parser Parser, uri string, reader io.Reader, emit func(domain.Transaction) error) error {
+	switch p := parser.(type) {
+	case NamedStreamingParser:
+		return p.StreamNamed(ctx, uri, reader, emit)
+	case StreamingParser:
+		return p.Stream(ctx, reader, emit)
+	}
+	return domain.NewValidationError("input format cannot be streamed", map[string]interface{}{"uri": uri})
+}
+
+// FilteredParser parses an input keeping only the transactions matching a filter,
+// without holding the rest in memory
+type FilteredParser interface {
+	StreamWithFilter(ctx context.Context, reader io.Reader, filterFunc func(domain.Transaction) bool, emit func(domain.Transaction) error) error
+	ParseWithPeriodFilter(ctx context.Context, reader io.Reader, year, month int) ([]domain.Transaction, error)
+	ParseWithDateRangeFilter(ctx context.Context, reader io.Reader, startDate, endDate time.Time) ([]domain.Transaction, error)
+}
@@ -16,6 +16,8 @@ type StatementService interface {
 type StatementServiceImpl struct {
 	TransactionService TransactionService
 	Writer             output.Writer
+	// Deduplicator is optional; nil keeps every transaction
+	Deduplicator *Deduplicator
 }
 
 func NewStatementService(transactionService TransactionService, writer output.Writer) StatementService {
@@ -25,27 +27,30 @@ func NewStatementService(transactionService TransactionService, writer output.Wr
 	}
 }
 
+// NewDedupingStatementService creates a statement service that removes duplicate
+// transactions before computing totals
+func NewDedupingStatementService(transactionService TransactionService, writer output.Writer, deduplicator *Deduplicator) StatementService {
+	return &StatementServiceImpl{
+		TransactionService: transactionService,
+		Writer:             writer,
+		Deduplicator:       deduplicator,
+	}
+}
+
 func (s *StatementServiceImpl) GenerateMonthlyStatement(ctx context.Context, csvFileURI string, periodDisplay string, year, month int) error {
 	transactions, err := s.TransactionService.GetTransactionsByPeriod(ctx, csvFileURI, year, month)
 	if err != nil {
 		return err
 	}
 
-	totalIncome, totalExpenditure := s.TransactionService.CalculateTotals(transactions)
-
-	statement := domain.NewStatement(periodDisplay, transactions, totalIncome, totalExpenditure)
-
-	if err := s.Writer.Write(ctx, statement); err != nil {
-		return domain.NewIOError("failed to write statement", err)
-	}
-
-	return nil
+	return s.GenerateStatementFromTransactions(ctx, transactions, periodDisplay)
 }
 
 func (s *StatementServiceImpl) GenerateStatementFromTransactions(ctx context.Context, transactions []domain.Transaction, periodDisplay string) error {
-	totalIncome, totalExpenditure := s.TransactionService.CalculateTotals(transactions)
-
-	statement := domain.NewStatement(periodDisplay, transactions, totalIncome, totalExpenditure)
+	statement, err := BuildStatement(s.TransactionService, s.Deduplicator, periodDisplay, transactions)
+	if err != nil {
+		return err
+	}
 
 	if err := s.Writer.Write(ctx, statement); err != nil {
 		return domain.NewIOError("failed to write statement", err)
@@ -62,3 +67,19 @@ func (s *StatementServiceImpl) GenerateStatementByDateRange(ctx context.Context,
 
 	return s.GenerateStatementFromTransactions(ctx, transactions, periodDisplay)
 }
+
+// BuildStatement de-duplicates transactions when a deduplicator is given and totals the rest.
+// In report mode the duplicates are listed in the statement's duplicates section.
+func BuildStatement(transactionService TransactionService, deduplicator *Deduplicator, periodDisplay string, transactions []domain.Transaction) (domain.Statement, error) {
+	kept, duplicates, err := deduplicator.Dedupe(transactions)
+	if err != nil {
+		return domain.Statement{}, err
+	}
+
+	totalIncome, totalExpenditure := transactionService.CalculateTotals(kept)
+	statement := domain.NewStatement(periodDisplay, kept, totalIncome, totalExpenditure)
+	if deduplicator != nil && deduplicator.Mode == DedupeReport {
+		statement.Duplicates = domain.NewTransactionDTOs(duplicates)
+	}
+	return statement, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"mf-statement/internal/adapters/out/output"
+	"mf-statement/internal/domain"
+)
+
+// StreamingStatementService writes the statement of a period while its inputs are
+// parsed: each transaction in input order, then the summary. Unlike StatementService
+// the period is never held in memory, so the parser must be able to stream.
+type StreamingStatementService struct {
+	Source    Source
+	Parser    Parser
+	Validator Validator
+}
+
+func NewStreamingStatementService(source Source, parser Parser) *StreamingStatementService {
+	return &StreamingStatementService{
+		Source:    source,
+		Parser:    parser,
+		Validator: NewPeriodValidator(),
+	}
+}
+
+// StreamMonthlyStatement streams the period's transactions of every input in turn,
+// tagging them with their input when there are several
+func (s *StreamingStatementService) StreamMonthlyStatement(ctx context.Context, uris []string, periodDisplay string, year, month int, streamWriter output.StreamWriter) error {
+	if err := s.Validator.ValidatePeriod(year, month); err != nil {
+		return err
+	}
+
+	summary := domain.Summary{Period: periodDisplay}
+	for _, uri := range uris {
+		if err := s.streamInput(ctx, uri, len(uris) > 1, year, month, &summary, streamWriter); err != nil {
+			if len(uris) > 1 {
+				return withSource(err, uri)
+			}
+			return err
+		}
+	}
+
+	if err := streamWriter.WriteSummary(ctx, summary); err != nil {
+		return domain.NewIOError("failed to write statement summary", err)
+	}
+	return nil
+}
+
+func (s *StreamingStatementService) streamInput(ctx context.Context, uri string, tag bool, year, month int, summary *domain.Summary, streamWriter output.StreamWriter) error {
+	reader, err := s.Source.Open(ctx, uri)
+	if err != nil {
+		return domain.NewIOError("failed to open CSV source", err)
+	}
+	defer reader.Close()
+
+	var writeErr error
+	err = StreamInput(ctx, s.Parser, uri, reader, func(transaction domain.Transaction) error {
+		if transaction.Date.Year() != year || int(transaction.Date.Month()) != month {
+			return nil
+		}
+		if tag {
+			transaction.Source = uri
+		}
+		addToSummary(summary, transaction)
+
+		if writeErr = streamWriter.WriteTransaction(ctx, transaction); writeErr != nil {
+			writeErr = domain.NewIOError("failed to write transaction", writeErr)
+		}
+		return writeErr
+	})
+	switch {
+	case writeErr != nil:
+		return writeErr
+	case domain.IsValidationError(err):
+		return err
+	case err != nil:
+		return domain.NewParseError("failed to parse CSV", err)
+	}
+	return nil
+}
+
+// addToSummary counts a transaction into the running totals of a streamed statement
+func addToSummary(summary *domain.Summary, transaction domain.Transaction) {
+	if transaction.IsIncome() {
+		summary.TotalIncome += transaction.Amount
+	} else if transaction.IsExpense() {
+		summary.TotalExpenditure += transaction.Amount
+	}
+	summary.TransactionCount++
+}
@@ -36,7 +36,7 @@ func (s *TransactionServiceImpl) GetAllTransactions(ctx context.Context, csvFile
 	}
 	defer csvReader.Close()
 
-	transactions, err := s.Parser.Parse(ctx, csvReader)
+	transactions, err := ParseInput(ctx, s.Parser, csvFileURI, csvReader)
 	if err != nil {
 		return nil, domain.NewParseError("failed to parse CSV", err)
 	}
@@ -54,18 +54,7 @@ func (s *TransactionServiceImpl) GetTransactionsByPeriod(ctx context.Context, cs
 		return nil, err
 	}
 
-	var filteredTransactions []domain.Transaction
-	for _, transaction := range allTransactions {
-		if transaction.Date.Year() == year && int(transaction.Date.Month()) == month {
-			filteredTransactions = append(filteredTransactions, transaction)
-		}
-	}
-
-	sort.SliceStable(filteredTransactions, func(i, j int) bool {
-		return filteredTransactions[i].Date.After(filteredTransactions[j].Date)
-	})
-
-	return filteredTransactions, nil
+	return FilterByPeriod(allTransactions, year, month), nil
 }
 
 func (s *TransactionServiceImpl) GetTransactionsByDateRange(ctx context.Context, csvFileURI string, startDate, endDate time.Time) ([]domain.Transaction, error) {
@@ -98,3 +87,22 @@ func (s *TransactionServiceImpl) CalculateTotals(transactions []domain.Transacti
 	}
 	return totalIncome, totalExpenditure
 }
+
+// FilterByPeriod returns the transactions of the given month, newest first
+func FilterByPeriod(transactions []domain.Transaction, year, month int) []domain.Transaction {
+	var filteredTransactions []domain.Transaction
+	for _, transaction := range transactions {
+		if transaction.Date.Year() == year && int(transaction.Date.Month()) == month {
+			filteredTransactions = append(filteredTransactions, transaction)
+		}
+	}
+
+	sortNewestFirst(filteredTransactions)
+	return filteredTransactions
+}
+
+func sortNewestFirst(transactions []domain.Transaction) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Date.After(transactions[j].Date)
+	})
+}